@@ -0,0 +1,285 @@
+// Command atlasgen rasterizes a TTF or BDF font, or converts a PNG atlas, into a Go source file holding
+// a dejavu.Atlas, it is run by go generate in the dejavu package.
+//
+//	atlasgen (-ttf file -size pt [-dpi 72] [-hinting] | -bdf file | -png file -width n) [-runes 0x20-0x7e,...]
+//		[-ascent n] [-descent n] -var name [-pkg name] [-o file]
+//
+// TTF fonts are rasterized by the pure Go rasterizer of golang.org/x/image, the same flags always
+// produce the same atlas. -ascent and -descent override the metrics of the font to fit the cells
+// of other fonts, pixels outside the cells are clipped. A PNG atlas has the glyphs of runes drawn
+// white on black in cells of -width x (-ascent + -descent) pixels stacked vertically.
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"go/format"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/coyove/common/dejavu"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+var (
+	ttf     = flag.String("ttf", "", "TrueType or OpenType font to rasterize")
+	size    = flag.Float64("size", 12, "size of the TTF font in points")
+	dpi     = flag.Float64("dpi", 72, "resolution of the TTF font")
+	hinting = flag.Bool("hinting", false, "hint TTF glyphs to the pixel grid")
+	bdf     = flag.String("bdf", "", "BDF font to convert")
+	pngFile = flag.String("png", "", "PNG atlas to convert")
+	width   = flag.Int("width", 0, "width of the cells of the PNG atlas")
+	runes   = flag.String("runes", "0x20-0x7e", "comma separated runes and ranges of runes")
+	ascent  = flag.Int("ascent", -1, "override the ascent of the font")
+	descent = flag.Int("descent", -1, "override the descent of the font")
+	name    = flag.String("var", "", "name of the generated variable")
+	pkg     = flag.String("pkg", os.Getenv("GOPACKAGE"), "package of the generated file")
+	output  = flag.String("o", "", "output file, stdout if empty")
+)
+
+func main() {
+	flag.Parse()
+	sources := 0
+	for _, s := range []string{*ttf, *bdf, *pngFile} {
+		if s != "" {
+			sources++
+		}
+	}
+
+	if *name == "" || *pkg == "" || sources != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "atlasgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	rs, err := parseRunes(*runes)
+	if err != nil {
+		return err
+	}
+
+	var f *dejavu.Font
+	switch {
+	case *ttf != "":
+		f, err = loadTTF(*ttf, rs)
+	case *bdf != "":
+		f, err = loadBDF(*bdf)
+	default:
+		f, err = loadPNG(*pngFile, rs)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *ascent >= 0 {
+		f.Ascent = *ascent
+	}
+	if *descent >= 0 {
+		f.Descent = *descent
+	}
+
+	a := dejavu.NewAtlas(f, rs)
+	if len(a.Runes) < len(rs) {
+		fmt.Fprintf(os.Stderr, "atlasgen: %d runes are not covered by the font\n", len(rs)-len(a.Runes))
+	}
+
+	data, err := a.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	src, err := source(a, data)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(*output, src, 0644)
+}
+
+// parseRunes parses lists like "0x20-0x7e,0xb0,é"
+func parseRunes(s string) ([]rune, error) {
+	var res []rune
+	seen := map[rune]bool{}
+
+	parse := func(s string) (rune, error) {
+		if r := []rune(s); len(r) == 1 {
+			return r[0], nil
+		}
+		v, err := strconv.ParseInt(s, 0, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid rune %q", s)
+		}
+		return rune(v), nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+
+		from, to := part, part
+		if i := strings.Index(part[1:], "-"); i >= 0 {
+			from, to = part[:i+1], part[i+2:]
+		}
+
+		a, err := parse(from)
+		if err != nil {
+			return nil, err
+		}
+		b, err := parse(to)
+		if err != nil {
+			return nil, err
+		}
+
+		for r := a; r <= b; r++ {
+			if !seen[r] {
+				seen[r] = true
+				res = append(res, r)
+			}
+		}
+	}
+
+	// atlases are encoded with runes in ascending order
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res, nil
+}
+
+func loadTTF(path string, rs []rune) (*dejavu.Font, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	otf, err := opentype.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	h := font.HintingNone
+	if *hinting {
+		h = font.HintingFull
+	}
+
+	face, err := opentype.NewFace(otf, &opentype.FaceOptions{Size: *size, DPI: *dpi, Hinting: h})
+	if err != nil {
+		return nil, err
+	}
+	defer face.Close()
+
+	m := face.Metrics()
+	f := dejavu.NewFont(m.Ascent.Ceil(), m.Descent.Ceil(), 0)
+
+	var buf sfnt.Buffer
+	for _, r := range rs {
+		if idx, err := otf.GlyphIndex(&buf, r); err != nil || idx == 0 {
+			continue
+		}
+
+		dr, mask, mp, adv, ok := face.Glyph(fixed.Point26_6{}, r)
+		if !ok {
+			continue
+		}
+
+		m := image.NewAlpha(dr)
+		draw.Draw(m, dr, mask, mp, draw.Src)
+		f.Add(r, &dejavu.Glyph{Mask: m, Advance: int(math.Round(float64(adv) / 64))})
+	}
+	return f, nil
+}
+
+func loadBDF(path string) (*dejavu.Font, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return dejavu.LoadBDF(file)
+}
+
+func loadPNG(path string, rs []rune) (*dejavu.Font, error) {
+	if *width <= 0 || *ascent < 0 || *descent < 0 {
+		return nil, fmt.Errorf("-width, -ascent and -descent are required by -png")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, err := png.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+
+	h := *ascent + *descent
+	b := img.Bounds()
+	if b.Dx() != *width || b.Dy() != len(rs)*h {
+		return nil, fmt.Errorf("%s is %dx%d, expect %d cells of %dx%d", path, b.Dx(), b.Dy(), len(rs), *width, h)
+	}
+
+	m := image.NewAlpha(image.Rect(0, 0, b.Dx(), b.Dy()))
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			m.Pix[m.PixOffset(x, y)] = color.GrayModel.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.Gray).Y
+		}
+	}
+
+	a := &dejavu.Atlas{Image: m, Width: *width, Ascent: *ascent, Descent: *descent, Runes: rs}
+	return a.Font(0), nil
+}
+
+func source(a *dejavu.Atlas, data []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "// Code generated by atlasgen %s; DO NOT EDIT.\n\n", strings.Join(os.Args[1:], " "))
+	fmt.Fprintf(buf, "package %s\n\n", *pkg)
+
+	// atlases generated into dejavu itself are registered and decoded on first use, so this
+	// command never runs the decoding of the atlases it is regenerating
+	fmt.Fprintf(buf, "// %s holds %d glyphs of %dx%d pixels\n", *name, len(a.Runes), a.Width, a.Ascent+a.Descent)
+	if *pkg == "dejavu" {
+		fmt.Fprintf(buf, "func init() {\n\tgeneratedAtlases[%q] = \"\" +\n", *name)
+	} else {
+		buf.WriteString("import \"github.com/coyove/common/dejavu\"\n\n")
+		fmt.Fprintf(buf, "var %s = dejavu.MustDecodeAtlas(\"\" +\n", *name)
+	}
+
+	b64 := base64.StdEncoding.EncodeToString(data)
+	for len(b64) > 0 {
+		n := 96
+		if n > len(b64) {
+			n = len(b64)
+		}
+		fmt.Fprintf(buf, "\t%q +\n", b64[:n])
+		b64 = b64[n:]
+	}
+
+	if *pkg == "dejavu" {
+		buf.WriteString("\t\"\"\n}\n")
+	} else {
+		buf.WriteString("\t\"\")\n")
+	}
+
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,326 @@
+// Command conf lints, queries, edits and formats config files read by the config package.
+//
+//	conf lint [-schema file] [-allow-unknown] file...
+//	conf get file section.key
+//	conf set [-w] file section.key value
+//	conf fmt [-w | -l] file...
+//	conf diff file1 file2
+//	conf keygen
+//	conf encrypt (-key file | -key-env name) value
+//
+// Keys of the default section are written without the section part. It exits with 1 if
+// lint finds problems, get finds nothing, fmt -l lists files or diff finds differences.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/coyove/common/config"
+)
+
+var commands = map[string]func(args []string) (int, error){
+	"lint":    lint,
+	"get":     get,
+	"set":     set,
+	"fmt":     format,
+	"diff":    diff,
+	"keygen":  keygen,
+	"encrypt": encrypt,
+}
+
+func main() {
+	if len(os.Args) < 2 || commands[os.Args[1]] == nil {
+		usage()
+	}
+
+	code, err := commands[os.Args[1]](os.Args[2:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "conf:", err)
+		code = 2
+	}
+	os.Exit(code)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage:
+	conf lint [-schema file] [-allow-unknown] file...
+	conf get file section.key
+	conf set [-w] file section.key value
+	conf fmt [-w | -l] file...
+	conf diff file1 file2
+	conf keygen
+	conf encrypt (-key file | -key-env name) value`)
+	os.Exit(2)
+}
+
+// parseFlags parses flags of a command and checks the number of remaining arguments
+func parseFlags(fs *flag.FlagSet, args []string, min, max int) []string {
+	fs.Usage = usage
+	fs.Parse(args)
+	if fs.NArg() < min || (max >= 0 && fs.NArg() > max) {
+		usage()
+	}
+	return fs.Args()
+}
+
+func splitPath(path string) (section, key string) {
+	if i := strings.LastIndexByte(path, '.'); i >= 0 {
+		return path[:i], path[i+1:]
+	}
+	return "default", path
+}
+
+func readDocument(path string) (*config.Document, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := config.ParseDocument(string(buf))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return doc, nil
+}
+
+// writeFile replaces the content of path and keeps its permissions
+func writeFile(path string, doc *config.Document) error {
+	st, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(doc.String()), st.Mode().Perm())
+}
+
+func lint(args []string) (int, error) {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	schemaPath := fs.String("schema", "", "validate against the schema in `file`")
+	allowUnknown := fs.Bool("allow-unknown", false, "don't report keys not covered by the schema")
+	files := parseFlags(fs, args, 1, -1)
+
+	var schema *config.Schema
+	if *schemaPath != "" {
+		buf, err := os.ReadFile(*schemaPath)
+		if err != nil {
+			return 0, err
+		}
+
+		if schema, err = config.ParseSchema(string(buf)); err != nil {
+			return 0, fmt.Errorf("%s: %w", *schemaPath, err)
+		}
+		schema.AllowUnknown = *allowUnknown
+	}
+
+	code := 0
+	for _, path := range files {
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			return 0, err
+		}
+
+		cf, err := config.ParseConf(string(buf))
+		if err != nil {
+			var errs config.ConfErrors
+			if !errors.As(err, &errs) {
+				return 0, fmt.Errorf("%s: %w", path, err)
+			}
+
+			for _, e := range errs {
+				fmt.Printf("%s: %v\n", path, e)
+			}
+			code = 1
+			continue
+		}
+
+		if schema == nil {
+			continue
+		}
+
+		if err := schema.Validate(cf); err != nil {
+			for _, v := range err.(config.Violations) {
+				fmt.Printf("%s: %v\n", path, v)
+			}
+			code = 1
+		}
+	}
+	return code, nil
+}
+
+func get(args []string) (int, error) {
+	args = parseFlags(flag.NewFlagSet("get", flag.ExitOnError), args, 2, 2)
+	doc, err := readDocument(args[0])
+	if err != nil {
+		return 0, err
+	}
+
+	cf, err := doc.Conf()
+	if err != nil {
+		return 0, err
+	}
+
+	v, ok := cf.Get(args[1])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "%s: %s not found\n", args[0], args[1])
+		return 1, nil
+	}
+
+	switch v.(type) {
+	case string, float64:
+		// print the text as written so that large integers stay exact
+		v, _ = cf.GetRaw(splitPath(args[1]))
+		fmt.Println(v)
+	default:
+		text, err := config.FormatValue(v)
+		if err != nil {
+			return 0, err
+		}
+		fmt.Println(text)
+	}
+	return 0, nil
+}
+
+func set(args []string) (int, error) {
+	fs := flag.NewFlagSet("set", flag.ExitOnError)
+	write := fs.Bool("w", false, "write the result to the file instead of stdout")
+	args = parseFlags(fs, args, 3, 3)
+
+	doc, err := readDocument(args[0])
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := config.ParseValue(args[2])
+	if err != nil {
+		return 0, err
+	}
+
+	section, key := splitPath(args[1])
+	if err := doc.Set(section, key, v); err != nil {
+		return 0, err
+	}
+
+	if *write {
+		return 0, writeFile(args[0], doc)
+	}
+	fmt.Print(doc.String())
+	return 0, nil
+}
+
+func format(args []string) (int, error) {
+	fs := flag.NewFlagSet("fmt", flag.ExitOnError)
+	write := fs.Bool("w", false, "write the result to the files instead of stdout")
+	list := fs.Bool("l", false, "list files whose formatting differs")
+	files := parseFlags(fs, args, 1, -1)
+
+	code := 0
+	for _, path := range files {
+		doc, err := readDocument(path)
+		if err != nil {
+			return 0, err
+		}
+
+		old := doc.String()
+		doc.Format()
+
+		switch {
+		case *list:
+			if doc.String() != old {
+				fmt.Println(path)
+				code = 1
+			}
+		case *write:
+			if doc.String() != old {
+				if err := writeFile(path, doc); err != nil {
+					return 0, err
+				}
+			}
+		default:
+			fmt.Print(doc.String())
+		}
+	}
+	return code, nil
+}
+
+func diff(args []string) (int, error) {
+	args = parseFlags(flag.NewFlagSet("diff", flag.ExitOnError), args, 2, 2)
+	a, err := readDocument(args[0])
+	if err != nil {
+		return 0, err
+	}
+
+	b, err := readDocument(args[1])
+	if err != nil {
+		return 0, err
+	}
+
+	ca, err := a.Conf()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", args[0], err)
+	}
+
+	cb, err := b.Conf()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", args[1], err)
+	}
+
+	changes := config.Diff(ca, cb)
+	for _, c := range changes {
+		if c.Old != nil {
+			fmt.Printf("- [%s] %s = %s\n", c.Section, c.Key, formatValue(c.Old))
+		}
+		if c.New != nil {
+			fmt.Printf("+ [%s] %s = %s\n", c.Section, c.Key, formatValue(c.New))
+		}
+	}
+
+	if len(changes) > 0 {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func formatValue(v interface{}) string {
+	text, err := config.FormatValue(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return text
+}
+
+func keygen(args []string) (int, error) {
+	parseFlags(flag.NewFlagSet("keygen", flag.ExitOnError), args, 0, 0)
+	key, err := config.GenerateKey()
+	if err != nil {
+		return 0, err
+	}
+	fmt.Println(key)
+	return 0, nil
+}
+
+func encrypt(args []string) (int, error) {
+	fs := flag.NewFlagSet("encrypt", flag.ExitOnError)
+	keyPath := fs.String("key", "", "read the key from `file`")
+	keyEnv := fs.String("key-env", "", "read the base64 key from the environment variable `name`")
+	args = parseFlags(fs, args, 1, 1)
+
+	var kp config.KeyProvider
+	switch {
+	case *keyPath != "" && *keyEnv == "":
+		kp = config.FileKey(*keyPath)
+	case *keyEnv != "" && *keyPath == "":
+		kp = config.EnvKey(*keyEnv)
+	default:
+		usage()
+	}
+
+	text, err := config.Encrypt(kp, args[0])
+	if err != nil {
+		return 0, err
+	}
+	fmt.Println(text)
+	return 0, nil
+}
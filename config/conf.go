@@ -1,46 +1,212 @@
 package config
 
 import (
-	"bytes"
 	"fmt"
-	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
 
-var splitLines = regexp.MustCompile(`\r?\n[\s\t]*`)
+type conf_t struct {
+	sections map[string]*section_t
+	order    []string // section names in the order they first appear
+}
 
-type ConfError struct {
-	line  int
-	index int
-	text  string
+// section_t holds the values of a section and their texts before being converted,
+// raw has one text for each value of an array
+type section_t struct {
+	values    map[string]interface{}
+	raw       map[string][]string
+	keys      []string // keys in the order they first appear
+	parent    string
+	inherited map[string]bool // keys copied from the parent
 }
 
-func (e *ConfError) Error() string {
-	return fmt.Sprintf("unexpected %s at line %d:%d", e.text, e.line, e.index)
+func newConf() *conf_t {
+	c := &conf_t{sections: map[string]*section_t{}}
+	c.section("default")
+	return c
 }
 
-type conf_t map[string]map[string]interface{}
+// section returns the named section, creates it if needed
+func (c *conf_t) section(name string) *section_t {
+	sec := c.sections[name]
+	if sec == nil {
+		sec = &section_t{values: map[string]interface{}{}, raw: map[string][]string{}}
+		c.sections[name] = sec
+		c.order = append(c.order, name)
+	}
+	return sec
+}
 
 func (c *conf_t) getSection(section string) map[string]interface{} {
-	if sec, ok := (*c)[section]; ok {
-		return sec
+	if sec, ok := c.sections[section]; ok {
+		return sec.values
+	} else {
+		return c.sections["default"].values // return a dummy so Get* functions won't panic
+	}
+}
+
+func (c *conf_t) get(section, key string) (interface{}, bool) {
+	if sec, ok := c.sections[section]; ok {
+		v, ok := sec.values[key]
+		return v, ok
+	}
+	return nil, false
+}
+
+// set sets the value and its texts, appending to an existing key turns it into an array,
+// appending an array concatenates it
+func (sec *section_t) set(key string, v interface{}, raw []string, appending bool) {
+	ov, existed := sec.values[key]
+	if !existed {
+		sec.keys = append(sec.keys, key)
+	}
+
+	if !appending || !existed {
+		sec.values[key], sec.raw[key] = v, raw
+		return
+	}
+
+	arr, ok := ov.([]interface{})
+	if !ok {
+		arr = []interface{}{ov}
+	}
+
+	if va, ok := v.([]interface{}); ok {
+		arr = append(arr, va...)
 	} else {
-		return (*c)["default"] // return a dummy so Get* functions won't panic
+		arr = append(arr, v)
+	}
+	sec.values[key], sec.raw[key] = arr, append(sec.raw[key], raw...)
+}
+
+// inherit copies keys from parent sections to the sections inheriting them, it returns the name
+// of the first section whose parent doesn't exist or which inherits itself
+func (c *conf_t) inherit() (string, bool) {
+	const resolving, resolved = 1, 2
+	state := map[string]int{}
+
+	var resolve func(name string) bool
+	resolve = func(name string) bool {
+		sec := c.sections[name]
+		switch state[name] {
+		case resolving:
+			return false
+		case resolved:
+			return true
+		}
+
+		if sec.parent == "" {
+			state[name] = resolved
+			return true
+		}
+
+		state[name] = resolving
+		parent, ok := c.sections[sec.parent]
+		if !ok || !resolve(sec.parent) {
+			return false
+		}
+
+		sec.inherited = map[string]bool{}
+		for _, k := range parent.keys {
+			if _, ok := sec.values[k]; !ok {
+				sec.set(k, parent.values[k], parent.raw[k], false)
+				sec.inherited[k] = true
+			}
+		}
+
+		state[name] = resolved
+		return true
+	}
+
+	for _, name := range c.names() {
+		if !resolve(name) {
+			return name, false
+		}
+	}
+	return "", true
+}
+
+// names returns the names of all sections in sorted order
+func (c *conf_t) names() []string {
+	names := make([]string, 0, len(c.sections))
+	for name := range c.sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parent returns the section inherited by section, empty if there is none
+func (c *conf_t) Parent(section string) string {
+	if sec, ok := c.sections[section]; ok {
+		return sec.parent
 	}
+	return ""
 }
 
 func (c *conf_t) HasSection(section string) bool {
-	_, ok := (*c)[section]
+	_, ok := c.sections[section]
 	return ok
 }
 
+// Iterate calls callback with each key of section in the order they appear in the file
 func (c *conf_t) Iterate(section string, callback func(key string)) {
-	for k := range c.getSection(section) {
-		callback(k)
+	c.IterateValues(section, func(key string, value interface{}) {
+		callback(key)
+	})
+}
+
+// IterateValues calls callback with each key and its value in the order they appear in the file
+func (c *conf_t) IterateValues(section string, callback func(key string, value interface{})) {
+	sec, ok := c.sections[section]
+	if !ok {
+		return
+	}
+
+	for _, k := range sec.keys {
+		callback(k, sec.values[k])
 	}
 }
 
+// Sections returns the names of all sections in the order they appear in the file, "default" is always the first one
+func (c *conf_t) Sections() []string {
+	return append([]string{}, c.order...)
+}
+
+// SubSections returns sections nested directly under section, e.g. "server.http" and "server.grpc"
+// are sub-sections of "server", the section itself doesn't need to exist.
+// Sub-sections of the default section are top-level sections which don't contain dots.
+func (c *conf_t) SubSections(section string) []string {
+	var res []string
+	for _, name := range c.order {
+		if section == "default" {
+			if name != "default" && !strings.Contains(name, ".") {
+				res = append(res, name)
+			}
+		} else if strings.HasPrefix(name, section+".") && !strings.Contains(name[len(section)+1:], ".") {
+			res = append(res, name)
+		}
+	}
+	return res
+}
+
+// Get returns the value at a dotted path like "server.http.port", which is key "port" in section "server.http".
+// A path without dots is a key in the default section.
+func (c *conf_t) Get(path string) (interface{}, bool) {
+	section, key := splitPath(path)
+	return c.get(section, key)
+}
+
+// splitPath splits "a.b.c" into section "a.b" and key "c"
+func splitPath(path string) (section, key string) {
+	if idx := strings.LastIndexByte(path, '.'); idx >= 0 {
+		return path[:idx], path[idx+1:]
+	}
+	return "default", path
+}
+
 func (c *conf_t) GetString(section, key string, defaultvalue string) string {
 	if s, ok := c.getSection(section)[key].(string); ok {
 		return s
@@ -49,6 +215,9 @@ func (c *conf_t) GetString(section, key string, defaultvalue string) string {
 }
 
 func (c *conf_t) GetInt(section, key string, defaultvalue int64) int64 {
+	if n, ok := c.getInt64(section, key); ok {
+		return n
+	}
 	if s, ok := c.getSection(section)[key].(float64); ok {
 		return int64(s)
 	}
@@ -76,143 +245,108 @@ func (c *conf_t) GetArray(section, key string) []interface{} {
 	return nil
 }
 
+// GetMap returns the value of an inline table like {k = v}
+func (c *conf_t) GetMap(section, key string) map[string]interface{} {
+	if s, ok := c.getSection(section)[key].(map[string]interface{}); ok {
+		return s
+	}
+	return nil
+}
+
+// ParseConf parses the config text, if there are errors, all of them will be returned as ConfErrors.
+// Use errors.As to get a *ConfError instead of asserting the type of the error.
 func ParseConf(str string) (*conf_t, error) {
-	key, value, value2 := &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}
-	config := make(conf_t)
-	curSection := make(map[string]interface{})
-	config["default"] = curSection
-
-	for ln, line := range splitLines.Split(str, -1) {
-		key.Reset()
-		value.Reset()
-
-		idx, p, quote := 0, key, byte(0)
-
-	L:
-		for idx < len(line) {
-			c := line[idx]
-
-			switch c {
-			case '[':
-				if quote == 0 {
-					if e := strings.Index(line, "]"); e > 0 {
-						sec := line[1:e]
-						curSection = config[sec]
-						if curSection == nil {
-							curSection = make(map[string]interface{})
-							config[sec] = curSection
-						}
-						break L
-					} else {
-						return nil, &ConfError{ln, idx, string(c)}
-					}
-				} else {
-					p.WriteByte(c)
-				}
-			case ' ', '\t':
-				if quote != 0 {
-					p.WriteByte(c)
-				}
-			case '\'', '"':
-				if idx > 0 && line[idx-1] == '\\' {
-					// escape
-				} else if quote == 0 {
-					quote = c
-				} else if quote == c {
-					quote = 0
-				} else {
-					return nil, &ConfError{ln, idx, string(c)}
-				}
-
-				p.WriteByte(c)
-			case '#':
-				if quote == 0 {
-					break L
-				} else {
-					p.WriteByte(c)
-				}
-			case '=':
-				if quote != 0 {
-					p.WriteByte(c)
-				} else if p != value {
-					p = value
-				} else {
-					return nil, &ConfError{ln, idx, "="}
-				}
-			default:
-				p.WriteByte(c)
-			}
+	return parse(str, true)
+}
+
+// parse parses the config text, parents of sections are resolved if inherit is true
+func parse(str string, inherit bool) (*conf_t, error) {
+	config := newConf()
+	curSection := config.section("default")
+
+	var errs ConfErrors
+	parentErrs := map[string]*ConfError{}
 
-			idx++
+	for _, ll := range splitLines(str) {
+		s, err := scanLine(ll)
+		if err != nil {
+			errs = append(errs, err)
+			continue
 		}
 
-		if quote != 0 {
-			return nil, &ConfError{ln, idx, string(quote)}
+		if s.header {
+			curSection = config.section(s.section)
+			if s.parent != "" {
+				curSection.parent = s.parent
+				parentErrs[s.section] = ll.error(ErrBadParent, strings.IndexByte(ll.text, ':'), s.parent)
+			}
 		}
 
-		k := key.String()
+		k := string(s.key)
 		if curSection == nil || k == "" {
 			continue
 		}
 
-		value2.Reset()
-		v, idx := value.Bytes(), 0
-
-		for idx < len(v) {
-			if v[idx] == '\\' {
-				if idx == len(v)-1 {
-					return nil, &ConfError{ln, idx, value.String()}
-				}
-
-				switch v[idx+1] {
-				case 'n':
-					value2.WriteByte('\n')
-				case 'r':
-					value2.WriteByte('\r')
-				case 't':
-					value2.WriteByte('\t')
-				default:
-					value2.WriteByte(v[idx+1])
-				}
-				idx += 2
-			} else {
-				value2.WriteByte(v[idx])
-				idx++
-			}
+		if s.bracket != 0 {
+			curSection.set(k, s.inline, s.raw, true)
+			continue
 		}
 
-		v2 := value2.String()
-
-		_append := func(v interface{}) {
-			if ov, existed := curSection[k]; existed {
-				if arr, ok := ov.([]interface{}); ok {
-					arr = append(arr, v)
-				} else {
-					curSection[k] = []interface{}{ov, v}
-				}
-			} else {
-				curSection[k] = v
-			}
+		v2, err := decode(s.value, s.vpos, ll)
+		if err != nil {
+			errs = append(errs, err)
+			continue
 		}
 
-		switch v2 {
-		case "on", "yes", "true":
-			_append(true)
-		case "off", "no", "false":
-			_append(false)
-		default:
-			if len(v2) >= 2 && (v2[0] == '\'' || v2[0] == '"') {
-				v2 = v2[1 : len(v2)-1]
-			}
+		v, raw := parseValue(v2)
+		curSection.set(k, v, []string{raw}, true)
+	}
 
-			if num, err := strconv.ParseFloat(v2, 64); err == nil {
-				_append(num)
-			} else {
-				_append(v2)
-			}
+	if len(errs) == 0 && inherit {
+		if name, ok := config.inherit(); !ok {
+			errs = append(errs, parentErrs[name])
 		}
+	}
 
+	if len(errs) > 0 {
+		return nil, errs
 	}
 
-	return &config, nil
+	return config, nil
+}
+
+// ParseValue parses text as it is written after '=', e.g. `on`, `"a b"`, `[1, 2]` or `{x = 1}`
+func ParseValue(text string) (interface{}, error) {
+	if len(splitLines("v = "+text)) != 1 {
+		return nil, fmt.Errorf("invalid value: %q", text)
+	}
+
+	c, err := ParseConf("v = " + text)
+	if err != nil {
+		return nil, err
+	}
+
+	v, _ := c.get("default", "v")
+	return v, nil
+}
+
+// parseValue converts the unescaped text of a value into bool, float64 or string,
+// the text without quotes is returned as raw. Hex, octal, binary and underscored integers
+// like 0x1F stay strings as they always did, GetInt64 and GetUint64 parse them from raw.
+func parseValue(v string) (value interface{}, raw string) {
+	switch v {
+	case "on", "yes", "true":
+		return true, v
+	case "off", "no", "false":
+		return false, v
+	}
+
+	if len(v) >= 2 && (v[0] == '\'' || v[0] == '"') {
+		v = v[1 : len(v)-1]
+	}
+
+	if num, err := strconv.ParseFloat(v, 64); err == nil {
+		return num, v
+	}
+	return v, v
 }
@@ -1,9 +1,20 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 )
 
+func firstError(err error) *ConfError {
+	var ce *ConfError
+	if errors.As(err, &ce) {
+		return ce
+	}
+	return nil
+}
+
 func TestConfParsing(t *testing.T) {
 	t.Log("Test conf file parsing")
 
@@ -90,7 +101,7 @@ func TestConfParsing(t *testing.T) {
 	a=b`
 
 	cf, err = ParseConf(text)
-	if err != nil && err.(*ConfError).text == "[" {
+	if firstError(err) != nil && firstError(err).text == "[" {
 		// ok
 	} else {
 		throw()
@@ -99,7 +110,7 @@ func TestConfParsing(t *testing.T) {
 	text = `a= ='#v'`
 
 	cf, err = ParseConf(text)
-	if err != nil && err.(*ConfError).text == "=" {
+	if firstError(err) != nil && firstError(err).text == "=" {
 		// ok
 	} else {
 		throw()
@@ -108,7 +119,7 @@ func TestConfParsing(t *testing.T) {
 	text = `a= 'text\'`
 
 	cf, err = ParseConf(text)
-	if err != nil && err.(*ConfError).text == "'" {
+	if firstError(err) != nil && firstError(err).text == "'" {
 		// ok
 	} else {
 		throw()
@@ -117,9 +128,274 @@ func TestConfParsing(t *testing.T) {
 	text = `a= "incomplete #comment`
 
 	cf, err = ParseConf(text)
-	if err != nil && err.(*ConfError).text == "\"" {
+	if firstError(err) != nil && firstError(err).text == "\"" {
 		// ok
 	} else {
 		throw()
 	}
 }
+
+func TestConfEnv(t *testing.T) {
+	t.Setenv("CONF_TEST_HOST", "example.com")
+	t.Setenv("APP_PORT", "8080")
+	t.Setenv("APP_SERVER_DEBUG", "on")
+
+	cf, err := ParseConf(`host = "http://${CONF_TEST_HOST}/"
+	user = ${CONF_TEST_USER:-nobody}
+	literal = '${CONF_TEST_HOST}'
+	escaped = \${CONF_TEST_HOST}
+	port = 80
+	[server]
+	debug = off`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cf.ApplyEnv("APP")
+
+	if cf.GetString("default", "host", "") != "http://example.com/" ||
+		cf.GetString("default", "user", "") != "nobody" ||
+		cf.GetString("default", "literal", "") != "${CONF_TEST_HOST}" ||
+		cf.GetString("default", "escaped", "") != "${CONF_TEST_HOST}" ||
+		cf.GetInt("default", "port", 0) != 8080 ||
+		cf.GetBool("server", "debug", false) != true {
+		t.Fatal(cf.sections)
+	}
+
+	if _, err := ParseConf(`a = ${UNTERMINATED`); err == nil {
+		t.Fatal("unterminated ${ should fail")
+	}
+}
+
+func TestConfErrors(t *testing.T) {
+	_, err := ParseConf("a = 1\r\n\r\n  [section\n\tb = it's\nc = 'x' = 'y'\nd = ok\\")
+
+	errs, ok := err.(ConfErrors)
+	if !ok || len(errs) != 4 {
+		t.Fatal(err)
+	}
+
+	for i, e := range []struct {
+		line, column int
+		code         ErrCode
+	}{
+		{3, 3, ErrUnclosedSection},
+		{4, 8, ErrUnclosedQuote},
+		{5, 9, ErrUnexpectedEqual},
+		{6, 7, ErrTrailingBackslash},
+	} {
+		if errs[i].Line() != e.line || errs[i].Column() != e.column || errs[i].Code() != e.code {
+			t.Fatal(i, errs[i])
+		}
+	}
+
+	if errs[1].Excerpt() != "\tb = it's\n\t      ^" {
+		t.Fatalf("%q", errs[1].Excerpt())
+	}
+}
+
+func TestConfTypes(t *testing.T) {
+	cf, err := ParseConf(`big = 9007199254740993
+	huge = 18446744073709551615
+	bigger = 1234567890123456789012345678901234567890
+	hex = 0x1F
+	oct = 0o17
+	dec = 017
+	float = 1.5
+	timeout = 1m30s
+	interval = 10
+	size = 64MB
+	cache = 1.5GiB
+	bad = 12XB
+	date = 2020-01-02
+	at = "2020-01-02 03:04:05"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cf.GetInt64("default", "big", 0) != 9007199254740993 || cf.GetInt("default", "big", 0) != 9007199254740993 ||
+		cf.GetUint64("default", "huge", 0) != 18446744073709551615 || cf.GetInt64("default", "huge", -1) != -1 ||
+		cf.GetBigInt("default", "bigger").String() != "1234567890123456789012345678901234567890" ||
+		cf.GetInt64("default", "hex", 0) != 31 || cf.GetInt64("default", "oct", 0) != 15 || cf.GetInt64("default", "dec", 0) != 17 ||
+		cf.GetInt64("default", "float", -1) != -1 || cf.GetInt("default", "float", -1) != 1 {
+		t.Fatal("integers")
+	}
+
+	if cf.GetDuration("default", "timeout", 0) != 90*time.Second || cf.GetDuration("default", "interval", 0) != 10*time.Second ||
+		cf.GetDuration("default", "size", -1) != -1 {
+		t.Fatal("durations")
+	}
+
+	if cf.GetBytesSize("default", "size", 0) != 64e6 || cf.GetBytesSize("default", "cache", 0) != 3<<29 ||
+		cf.GetBytesSize("default", "big", 0) != 9007199254740993 || cf.GetBytesSize("default", "bad", -1) != -1 {
+		t.Fatal("sizes")
+	}
+
+	if !cf.GetTime("default", "date", time.Time{}).Equal(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)) ||
+		!cf.GetTime("default", "at", time.Time{}).Equal(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Fatal("times")
+	}
+
+	// non-decimal integers keep their old string values
+	if cf.GetString("default", "hex", "") != "0x1F" || cf.GetString("default", "oct", "") != "0o17" {
+		t.Fatal("strings")
+	}
+
+	if s, _ := cf.GetRaw("default", "hex"); s != "0x1F" {
+		t.Fatal("raw", s)
+	}
+}
+
+func TestConfMultiLine(t *testing.T) {
+	text := "text = \"\"\"\r\nfirst line\r\n  [not a section]\r\n\\tthird=3\"\"\"\r\n" +
+		"literal = '''${HOME} # not a comment'''\n" +
+		"joined = one, \\\n   two, \\\n   three\n" +
+		"quoted = \"a \\\n   b\"\n" +
+		"arr = [1, 'two', [3, 0x4], {k = v}]\n" +
+		"arr = 5\n" +
+		"tbl = {name = \"x y\", ports = [80, 443], on = yes}\n" +
+		"empty = []\n" +
+		"path = C:\\\\dir\\\\"
+
+	cf, err := ParseConf(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cf.GetString("default", "text", "") != "first line\n  [not a section]\n\tthird=3" ||
+		cf.GetString("default", "literal", "") != "${HOME} # not a comment" ||
+		cf.GetString("default", "joined", "") != "one,two,three" ||
+		cf.GetString("default", "quoted", "") != "a b" ||
+		cf.GetString("default", "path", "") != `C:\dir\` {
+		t.Fatal(cf.sections["default"].values)
+	}
+
+	arr := cf.GetArray("default", "arr")
+	if len(arr) != 5 || arr[0] != 1.0 || arr[1] != "two" || arr[4] != 5.0 ||
+		arr[2].([]interface{})[1] != "0x4" || arr[3].(map[string]interface{})["k"] != "v" {
+		t.Fatal(arr)
+	}
+
+	if raw, _ := cf.GetRaw("default", "arr"); raw != "1" || len(cf.sections["default"].raw["arr"]) != 5 {
+		t.Fatal(cf.sections["default"].raw["arr"])
+	}
+
+	tbl := cf.GetMap("default", "tbl")
+	if tbl["name"] != "x y" || len(tbl["ports"].([]interface{})) != 2 || tbl["on"] != true {
+		t.Fatal(tbl)
+	}
+
+	if arr := cf.GetArray("default", "empty"); arr == nil || len(arr) != 0 {
+		t.Fatal(arr)
+	}
+
+	_, err = ParseConf("a = [1, 2\nb = [1 2]\nc = '''\nunclosed")
+	errs, _ := err.(ConfErrors)
+	if len(errs) != 3 || errs[0].Code() != ErrUnclosedBracket || errs[1].Code() != ErrBadInline || errs[1].Column() != 8 ||
+		errs[2].Code() != ErrUnclosedQuote || errs[2].Line() != 3 {
+		t.Fatal(err)
+	}
+
+	// values which are not inline tables keep their old meanings
+	cf, err = ParseConf("a = {name}\nb = {x\nc = {a b}")
+	if err != nil || cf.GetString("default", "a", "") != "{name}" || cf.GetString("default", "b", "") != "{x" ||
+		cf.GetString("default", "c", "") != "{ab}" {
+		t.Fatal(err)
+	}
+
+	if _, err := ParseConf("a = [x"); err == nil {
+		t.Fatal("unclosed array")
+	}
+
+	doc, err := ParseDocument(text)
+	if err != nil || doc.String() != text {
+		t.Fatal(err, doc.String())
+	}
+
+	doc.Set("default", "joined", "four")
+	doc.Set("default", "text", "short")
+	doc.Set("default", "tbl", "[1]")
+	if cf, _ := doc.Conf(); cf.GetString("default", "joined", "") != "four" || cf.GetString("default", "text", "") != "short" ||
+		cf.GetString("default", "tbl", "") != "[1]" || cf.GetString("default", "path", "") != `C:\dir\` {
+		t.Fatal(doc.String())
+	}
+}
+
+func TestConfNested(t *testing.T) {
+	cf, err := ParseConf(`z = 1
+	a = 2
+	[server]
+	name = web
+	[server.http]
+	port = 80
+	host = localhost
+	[server.http.tls]
+	cert = a.pem
+	[server.grpc]
+	port = 9000
+	[client]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := cf.Get("server.http.port"); !ok || v != 80.0 {
+		t.Fatal(v)
+	}
+
+	if v, ok := cf.Get("a"); !ok || v != 2.0 {
+		t.Fatal(v)
+	}
+
+	if _, ok := cf.Get("server.http.missing"); ok {
+		t.FailNow()
+	}
+
+	if fmt.Sprint(cf.Sections()) != "[default server server.http server.http.tls server.grpc client]" ||
+		fmt.Sprint(cf.SubSections("server")) != "[server.http server.grpc]" ||
+		fmt.Sprint(cf.SubSections("default")) != "[server client]" {
+		t.Fatal(cf.Sections(), cf.SubSections("server"))
+	}
+
+	var keys []string
+	cf.IterateValues("server.http", func(k string, v interface{}) { keys = append(keys, fmt.Sprint(k, "=", v)) })
+	cf.Iterate("default", func(k string) { keys = append(keys, k) })
+	if fmt.Sprint(keys) != "[port=80 host=localhost z a]" {
+		t.Fatal(keys)
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	for _, c := range []struct {
+		text  string
+		value interface{}
+	}{
+		{"on", true},
+		{"'on'", "on"},
+		{"0x10", "0x10"},
+		{"1e3", 1000.0},
+		{`"a b # c"`, "a b # c"},
+	} {
+		if v, err := ParseValue(c.text); err != nil || v != c.value {
+			t.Fatal(c, v, err)
+		}
+	}
+
+	if v, err := ParseValue("[1, {x = a}]"); err != nil || len(v.([]interface{})) != 2 {
+		t.Fatal(v, err)
+	}
+
+	if _, err := ParseValue("1\n[s]\nv = 2"); err == nil {
+		t.Fatal("multiple lines")
+	}
+
+	for _, v := range []interface{}{"it's", true, "true", []interface{}{1.0, "x y"}} {
+		text, err := FormatValue(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if v2, err := ParseValue(text); err != nil || fmt.Sprint(v2) != fmt.Sprint(v) {
+			t.Fatal(text, v2, err)
+		}
+	}
+}
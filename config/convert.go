@@ -0,0 +1,311 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// Configs are converted from and to JSON, YAML and TOML documents with the same layout:
+//
+//   - keys of the default section are at the top level of the document
+//   - other sections are tables (objects), nested sections like "server.http" are nested tables
+//   - strings, bools and arrays map to their counterparts, numbers which are exact integers map to integers
+//     and other numbers map to floats, inline tables inside arrays map to tables
+//
+// When importing, a table at the top level or directly inside a section becomes a (sub-)section, so exporting
+// a key whose value is an inline table fails rather than turning it into a section. Dates and times of YAML and TOML are imported
+// as RFC 3339 strings, which can be read by GetTime. Nulls are not supported.
+
+// ToJSON exports the config as an indented JSON document
+func (c *conf_t) ToJSON() ([]byte, error) {
+	tree, err := c.tree()
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(tree, "", "  ")
+}
+
+// ToYAML exports the config as a YAML document
+func (c *conf_t) ToYAML() ([]byte, error) {
+	tree, err := c.tree()
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(tree)
+}
+
+// ToTOML exports the config as a TOML document, arrays must contain elements of the same type
+func (c *conf_t) ToTOML() ([]byte, error) {
+	tree, err := c.tree()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := toml.NewEncoder(buf).Encode(tree); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// FromJSON imports a config from a JSON document whose top level is an object
+func FromJSON(data []byte) (*conf_t, error) {
+	var root map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&root); err != nil {
+		return nil, err
+	}
+	return fromTree(root)
+}
+
+// FromYAML imports a config from a YAML document whose top level is a mapping
+func FromYAML(data []byte) (*conf_t, error) {
+	var root map[string]interface{}
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	return fromTree(root)
+}
+
+// FromTOML imports a config from a TOML document
+func FromTOML(data []byte) (*conf_t, error) {
+	var root map[string]interface{}
+	if _, err := toml.Decode(string(data), &root); err != nil {
+		return nil, err
+	}
+	return fromTree(root)
+}
+
+// tree converts the config into nested maps
+func (c *conf_t) tree() (map[string]interface{}, error) {
+	root := map[string]interface{}{}
+
+	for _, name := range c.order {
+		sec, table := c.sections[name], root
+		if name != "default" {
+			var err error
+			if table, err = subTable(root, name); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, k := range sec.keys {
+			if _, ok := table[k]; ok {
+				return nil, fmt.Errorf("[%s] %s: conflicts with a section", name, k)
+			}
+
+			v := sec.values[k]
+			if _, ok := v.(map[string]interface{}); ok {
+				return nil, fmt.Errorf("[%s] %s: inline table would be imported as a section", name, k)
+			}
+
+			if arr, ok := v.([]interface{}); ok && len(sec.raw[k]) == len(arr) {
+				res := make([]interface{}, len(arr))
+				for i, v := range arr {
+					res[i] = exportValue(v, sec.raw[k][i])
+				}
+				table[k] = res
+			} else if len(sec.raw[k]) == 1 {
+				table[k] = exportValue(v, sec.raw[k][0])
+			} else {
+				table[k] = exportValue(v, "")
+			}
+		}
+	}
+	return root, nil
+}
+
+// subTable returns the table of a dotted section name, creates it if needed
+func subTable(root map[string]interface{}, name string) (map[string]interface{}, error) {
+	table, path := root, ""
+	for _, part := range splitDots(name) {
+		if path != "" {
+			path += "."
+		}
+		path += part
+
+		switch t := table[part].(type) {
+		case nil:
+			sub := map[string]interface{}{}
+			table[part], table = sub, sub
+		case map[string]interface{}:
+			table = t
+		default:
+			return nil, fmt.Errorf("section %s conflicts with a key", path)
+		}
+	}
+	return table, nil
+}
+
+func splitDots(name string) []string {
+	var parts []string
+	for {
+		section, key := splitPath(name)
+		parts = append([]string{key}, parts...)
+		if section == "default" {
+			return parts
+		}
+		name = section
+	}
+}
+
+// exportValue converts numbers whose raw text is an exact integer into int64
+func exportValue(v interface{}, raw string) interface{} {
+	switch v := v.(type) {
+	case float64:
+		if n, err := parseInt(raw); err == nil {
+			return n
+		}
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v)
+		}
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, v := range v {
+			res[i] = exportValue(v, "")
+		}
+		return res
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for k, v := range v {
+			res[k] = exportValue(v, "")
+		}
+		return res
+	}
+	return v
+}
+
+// fromTree builds a config from nested maps decoded from a document
+func fromTree(root map[string]interface{}) (*conf_t, error) {
+	c := newConf()
+	if err := c.importTable("default", root); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *conf_t) importTable(name string, table map[string]interface{}) error {
+	sec := c.section(name)
+
+	keys := make([]string, 0, len(table))
+	for k := range table {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var subs []string
+	for _, k := range keys {
+		if m, ok := toStringMap(table[k]); ok {
+			subs = append(subs, k)
+			table[k] = m
+			continue
+		}
+
+		v, raw, err := importValue(table[k])
+		if err != nil {
+			return fmt.Errorf("[%s] %s: %v", name, k, err)
+		}
+		sec.set(k, v, raw, false)
+	}
+
+	for _, k := range subs {
+		sub := k
+		if name != "default" {
+			sub = name + "." + k
+		}
+
+		if err := c.importTable(sub, table[k].(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importValue converts a decoded value into the types ParseConf produces, raw has one text
+// for each element if the value is an array
+func importValue(v interface{}) (interface{}, []string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, []string{v}, nil
+	case bool:
+		return v, []string{strconv.FormatBool(v)}, nil
+	case int:
+		return float64(v), []string{strconv.Itoa(v)}, nil
+	case int64:
+		return float64(v), []string{strconv.FormatInt(v, 10)}, nil
+	case uint64:
+		return float64(v), []string{strconv.FormatUint(v, 10)}, nil
+	case float64:
+		return v, []string{strconv.FormatFloat(v, 'g', -1, 64)}, nil
+	case json.Number:
+		f, err := strconv.ParseFloat(v.String(), 64)
+		return f, []string{v.String()}, err
+	case time.Time:
+		s := v.Format(time.RFC3339Nano)
+		return s, []string{s}, nil
+	case []map[string]interface{}:
+		arr := make([]interface{}, len(v))
+		for i, v := range v {
+			arr[i] = v
+		}
+		return importValue(arr)
+	case []interface{}:
+		arr, raws := make([]interface{}, len(v)), make([]string, len(v))
+		for i, e := range v {
+			if m, ok := toStringMap(e); ok {
+				e = m
+			}
+
+			ev, raw, err := importValue(e)
+			if err != nil {
+				return nil, nil, err
+			}
+			arr[i], raws[i] = ev, toRaw(ev)
+			if len(raw) == 1 {
+				raws[i] = raw[0]
+			}
+		}
+		return arr, raws, nil
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			if sm, ok := toStringMap(e); ok {
+				e = sm
+			}
+
+			ev, _, err := importValue(e)
+			if err != nil {
+				return nil, nil, err
+			}
+			m[k] = ev
+		}
+		return m, []string{toRaw(m)}, nil
+	case nil:
+		return nil, nil, fmt.Errorf("null is not supported")
+	}
+	return nil, nil, fmt.Errorf("unsupported type %T", v)
+}
+
+// toStringMap converts tables decoded by json, yaml or toml into map[string]interface{}
+func toStringMap(v interface{}) (map[string]interface{}, bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[fmt.Sprint(k)] = e
+		}
+		return m, true
+	}
+	return nil, false
+}
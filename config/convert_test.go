@@ -0,0 +1,99 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	cf, err := ParseConf(`name = app
+	big = 9007199254740993
+	ratio = 0.5
+	tags = [a, 1, true]
+	points = [{x = 1, y = 2}]
+	[server]
+	host = localhost
+	port = 0x50
+	[server.http]
+	timeout = 30
+	[db]
+	debug = off`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(format string, c *conf_t) {
+		get := func(c *conf_t, path string) interface{} {
+			v, _ := c.Get(path)
+			return v
+		}
+
+		for _, path := range []string{"name", "ratio", "server.host", "server.port", "server.http.timeout", "db.debug"} {
+			if a, b := get(cf, path), get(c, path); a != b {
+				t.Fatal(format, path, a, b)
+			}
+		}
+
+		if !reflect.DeepEqual(get(cf, "tags"), get(c, "tags")) || !reflect.DeepEqual(get(cf, "points"), get(c, "points")) {
+			t.Fatal(format, get(c, "tags"), get(c, "points"))
+		}
+
+		if v := c.GetInt64("default", "big", 0); v != 9007199254740993 {
+			t.Fatal(format, v)
+		}
+
+		if subs := c.SubSections("server"); len(subs) != 1 || subs[0] != "server.http" {
+			t.Fatal(format, subs)
+		}
+	}
+
+	buf, err := cf.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(buf), `"big": 9007199254740993`) || !strings.Contains(string(buf), `"port": "0x50"`) {
+		t.Fatal(string(buf))
+	}
+	c, err := FromJSON(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("json", c)
+
+	if buf, err = cf.ToYAML(); err != nil {
+		t.Fatal(err)
+	}
+	if c, err = FromYAML(buf); err != nil {
+		t.Fatal(err)
+	}
+	check("yaml", c)
+
+	if _, err = cf.ToTOML(); err == nil {
+		t.Fatal("mixed array")
+	}
+	cf.sections["default"].set("tags", []interface{}{"a", "b"}, []string{"a", "b"}, false)
+	if buf, err = cf.ToTOML(); err != nil {
+		t.Fatal(err)
+	}
+	if c, err = FromTOML(buf); err != nil {
+		t.Fatal(err)
+	}
+	check("toml", c)
+
+	if c, err = FromYAML([]byte("a:\n  b: null")); err == nil {
+		t.Fatal("null")
+	}
+
+	cf, _ = ParseConf(`a = 1
+	[a]
+	b = 2`)
+	if _, err = cf.ToJSON(); err == nil {
+		t.Fatal("conflict")
+	}
+
+	cf, _ = ParseConf("k = {x = 1}")
+	if _, err = cf.ToYAML(); err == nil {
+		t.Fatal("inline table")
+	}
+}
@@ -0,0 +1,473 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Document is a config file kept in its original form. Set and Delete only touch the lines
+// they have to, so comments, ordering, quoting and section layout survive a round trip.
+type Document struct {
+	lines []*docLine
+}
+
+type docLine struct {
+	text    string // without the line ending, may contain line breaks if it is a multi-line value
+	joined  string // text with continuations joined, positions below are in it, empty if it is the same as text
+	cr      bool   // line ended with "\r\n"
+	section string
+	parent  string
+	key     string
+	header  bool
+	eq      int
+	start   int
+	end     int
+}
+
+// ParseDocument parses str into a Document, it accepts the same syntax as ParseConf
+func ParseDocument(str string) (*Document, error) {
+	d := &Document{}
+	section := "default"
+	var errs ConfErrors
+
+	for _, ll := range splitLines(str) {
+		l := &docLine{}
+		if err := l.scan(ll, section); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		section = l.section
+		d.lines = append(d.lines, l)
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return d, nil
+}
+
+func (l *docLine) scan(ll *logicalLine, section string) *ConfError {
+	s, err := scanLine(ll)
+	if err != nil {
+		return err
+	}
+
+	l.text, l.joined, l.cr = ll.raw, "", ll.cr
+	if ll.raw != ll.text {
+		l.joined = ll.text
+	}
+
+	l.section, l.header = section, s.header
+	if s.header {
+		l.section, l.parent = s.section, s.parent
+	}
+
+	l.key, l.eq, l.start, l.end = string(s.key), s.eq, s.start, s.end
+	return nil
+}
+
+// src returns the text that positions of the line refer to
+func (l *docLine) src() string {
+	if l.joined != "" {
+		return l.joined
+	}
+	return l.text
+}
+
+// Conf parses the current content of the document
+func (d *Document) Conf() (*conf_t, error) {
+	return ParseConf(d.String())
+}
+
+// Set sets the value of key in section, value can be a string, bool, number, map[string]interface{}
+// or a slice of them, a slice is written as repeated keys and a map as an inline table. Existing lines are edited in place and keep their
+// quoting and trailing comments, new keys are appended to the section (which is created if needed).
+func (d *Document) Set(section, key string, value interface{}) error {
+	if key == "" || strings.ContainsAny(key, " \t\r\n=#['\"\\") {
+		return fmt.Errorf("invalid key: %q", key)
+	}
+
+	if section == "" || strings.ContainsAny(section, "]\r\n") {
+		return fmt.Errorf("invalid section: %q", section)
+	}
+
+	var values []interface{}
+	switch v := value.(type) {
+	case []interface{}:
+		values = v
+	case []string:
+		for _, s := range v {
+			values = append(values, s)
+		}
+	default:
+		values = []interface{}{v}
+	}
+
+	var found []int
+	for i, l := range d.lines {
+		if l.section == section && l.key == key {
+			found = append(found, i)
+		}
+	}
+
+	var newLines []*docLine
+
+	if len(found) > 0 {
+		tmpl := d.lines[found[0]]
+		src, head, suffix, quote := tmpl.src(), "", "", byte(0)
+
+		if tmpl.eq < 0 {
+			// key without a value
+			indent := len(src) - len(strings.TrimLeft(src, " \t"))
+			head = src[:indent] + key + " = "
+		} else {
+			head, suffix = src[:tmpl.start], src[tmpl.end:]
+			if raw := src[tmpl.start:tmpl.end]; raw != "" && (raw[0] == '\'' || raw[0] == '"') {
+				quote = raw[0]
+			}
+
+			if tmpl.start == tmpl.end && strings.HasPrefix(suffix, "#") {
+				suffix = " " + suffix
+			}
+		}
+
+		for _, v := range values {
+			fv, err := formatValue(v, quote)
+			if err != nil {
+				return err
+			}
+
+			l, err := d.newLine(head+fv+suffix, tmpl.cr, section)
+			if err != nil {
+				return err
+			}
+			newLines = append(newLines, l)
+		}
+
+		d.replace(found, newLines)
+		return nil
+	}
+
+	// Find the last key of the section and use it as the template of the new line
+	at, tmpl, hasSection := -1, (*docLine)(nil), section == "default"
+	for i, l := range d.lines {
+		if l.section != section {
+			continue
+		}
+
+		if l.header {
+			at, hasSection = i+1, true
+		} else if l.key != "" {
+			if at = i + 1; l.eq >= 0 {
+				tmpl = l
+			}
+		}
+	}
+
+	if at == -1 && hasSection {
+		// The default section has no keys, put new keys above the first section
+		// and the comments describing it
+		at = d.trailingEmpty()
+		for i, l := range d.lines {
+			if l.header {
+				at = i
+				for at > 0 && d.isComment(at-1) {
+					at--
+				}
+				break
+			}
+		}
+	}
+
+	prefix, cr := key+" = ", false
+	if tmpl != nil {
+		src := tmpl.src()
+		kend := len(strings.TrimRight(src[:tmpl.eq], " \t"))
+		indent := len(src) - len(strings.TrimLeft(src, " \t"))
+		prefix, cr = src[:indent]+key+src[kend:tmpl.start], tmpl.cr
+	} else if len(d.lines) > 0 {
+		cr = d.lines[0].cr
+	}
+
+	if !hasSection {
+		at = d.trailingEmpty()
+		if at > 0 && strings.TrimSpace(d.lines[at-1].text) != "" {
+			l, _ := d.newLine("", cr, d.lines[at-1].section)
+			newLines = append(newLines, l)
+		}
+
+		l, err := d.newLine("["+section+"]", cr, section)
+		if err != nil {
+			return err
+		}
+		newLines = append(newLines, l)
+	}
+
+	for _, v := range values {
+		fv, err := formatValue(v, 0)
+		if err != nil {
+			return err
+		}
+
+		l, err := d.newLine(prefix+fv, cr, section)
+		if err != nil {
+			return err
+		}
+		newLines = append(newLines, l)
+	}
+
+	d.lines = append(d.lines[:at], append(newLines, d.lines[at:]...)...)
+	return nil
+}
+
+// Delete removes all lines defining key in section and returns whether any was found
+func (d *Document) Delete(section, key string) bool {
+	var found []int
+	for i, l := range d.lines {
+		if l.section == section && l.key == key {
+			found = append(found, i)
+		}
+	}
+
+	d.replace(found, nil)
+	return len(found) > 0
+}
+
+// Format rewrites the document in a canonical form: indentation and trailing spaces are removed, keys are
+// written as "key = value" and headers as "[section]" or "[section : parent]", runs of blank lines are collapsed,
+// each section is preceded by a blank line and the text ends with a line break. Values and comments are kept
+// as they are, lines with continuations or multi-line values are left untouched.
+func (d *Document) Format() {
+	var res []*docLine
+	last := func() string { return res[len(res)-1].text }
+
+	for _, l := range d.lines {
+		text := l.format()
+		if text == "" && (len(res) == 0 || last() == "") {
+			continue
+		}
+
+		if l.header && len(res) > 0 {
+			// the blank line goes before the comments directly above the header
+			at := len(res)
+			for at > 0 && strings.HasPrefix(res[at-1].text, "#") {
+				at--
+			}
+
+			if at > 0 && res[at-1].text != "" {
+				blank := &docLine{cr: l.cr, section: res[at-1].section, eq: -1, start: -1, end: -1}
+				res = append(res[:at], append([]*docLine{blank}, res[at:]...)...)
+			}
+		}
+
+		if text != l.text {
+			if nl, err := d.newLine(text, l.cr, l.section); err == nil {
+				l = nl
+			}
+		}
+		res = append(res, l)
+	}
+
+	for len(res) > 0 && last() == "" {
+		res = res[:len(res)-1]
+	}
+
+	if len(res) > 0 {
+		end := res[len(res)-1]
+		res = append(res, &docLine{cr: end.cr, section: end.section, eq: -1, start: -1, end: -1})
+	}
+	d.lines = res
+}
+
+// format returns the canonical text of the line
+func (l *docLine) format() string {
+	if l.joined != "" || strings.Contains(l.text, "\n") {
+		return l.text
+	}
+
+	trimmed := strings.TrimSpace(l.text)
+	if trimmed == "" || trimmed[0] == '#' || (!l.header && l.key == "") {
+		return trimmed
+	}
+
+	var text, rest string
+	if l.header {
+		text = "[" + l.section
+		if l.parent != "" {
+			text += " : " + l.parent
+		}
+		text += "]"
+		rest = l.text[strings.IndexByte(l.text, ']')+1:]
+	} else {
+		text, rest = l.key, l.text
+		if l.eq >= 0 {
+			text, rest = text+" =", l.text[l.eq+1:]
+		}
+		if l.start >= 0 {
+			text, rest = text+" "+l.text[l.start:l.end], l.text[l.end:]
+		}
+	}
+
+	if i := strings.IndexByte(rest, '#'); i >= 0 {
+		text += " " + strings.TrimRight(rest[i:], " \t")
+	}
+	return text
+}
+
+// String returns the text of the document
+func (d *Document) String() string {
+	buf := &strings.Builder{}
+	d.WriteTo(buf)
+	return buf.String()
+}
+
+// WriteTo writes the text of the document into w
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	for i, l := range d.lines {
+		text := l.text
+		if i < len(d.lines)-1 {
+			if l.cr {
+				text += "\r"
+			}
+			text += "\n"
+		}
+
+		n, err := io.WriteString(w, text)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func (d *Document) newLine(text string, cr bool, section string) (*docLine, error) {
+	l := &docLine{}
+	if err := l.scan(splitLines(text)[0], section); err != nil {
+		return nil, err
+	}
+	l.cr = cr
+	return l, nil
+}
+
+// replace replaces the line at idx[0] with lines and removes the rest in idx
+func (d *Document) replace(idx []int, lines []*docLine) {
+	if len(idx) == 0 {
+		return
+	}
+
+	res := make([]*docLine, 0, len(d.lines)+len(lines))
+	for i, l := range d.lines {
+		if len(idx) > 0 && i == idx[0] {
+			res, lines = append(res, lines...), nil
+			idx = idx[1:]
+			continue
+		}
+		res = append(res, l)
+	}
+	d.lines = res
+}
+
+// trailingEmpty returns the index after the last non-empty line
+func (d *Document) trailingEmpty() int {
+	at := len(d.lines)
+	for at > 0 && strings.TrimSpace(d.lines[at-1].text) == "" {
+		at--
+	}
+	return at
+}
+
+func (d *Document) isComment(i int) bool {
+	return strings.HasPrefix(strings.TrimSpace(d.lines[i].text), "#")
+}
+
+// FormatValue formats v so that ParseValue will read it back
+func FormatValue(v interface{}) (string, error) {
+	return formatValue(v, 0)
+}
+
+// formatValue formats v so that ParseConf will read it back, quote is the preferred quote char of strings
+func formatValue(v interface{}, quote byte) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return quoteString(v, quote)
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, v := range v {
+			s, err := formatValue(v, quote)
+			if err != nil {
+				return "", err
+			}
+			items[i] = s
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case map[string]interface{}:
+		items := make([]string, 0, len(v))
+		for k, v := range v {
+			if k == "" || strings.ContainsAny(k, " \t\r\n=,{}[]'\"") {
+				return "", fmt.Errorf("invalid key: %q", k)
+			}
+
+			s, err := formatValue(v, quote)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, k+" = "+s)
+		}
+		sort.Strings(items)
+		return "{" + strings.Join(items, ", ") + "}", nil
+	}
+	return "", fmt.Errorf("unsupported value type: %T", v)
+}
+
+func quoteString(s string, quote byte) (string, error) {
+	switch s {
+	case "on", "yes", "true", "off", "no", "false":
+		// quoted so it will be read as a string
+	default:
+		if quote == 0 && s != "" && !strings.ContainsAny(s, " \t\r\n#=,[]{}'\"\\$") {
+			return s, nil
+		}
+	}
+
+	if quote == 0 {
+		quote = '"'
+	}
+
+	if strings.HasSuffix(s, "\\") {
+		return "", fmt.Errorf("string ending with a backslash can't be quoted: %q", s)
+	}
+
+	buf := &strings.Builder{}
+	buf.WriteByte(quote)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\\', '\'', '"', '$':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	buf.WriteByte(quote)
+	return buf.String(), nil
+}
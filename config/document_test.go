@@ -0,0 +1,88 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestDocument(t *testing.T) {
+	text := "# server config\r\na = 1 # the answer\r\nb='hello'\r\nc\r\n\r\n# section comment\r\n[section]\r\n  key=\"value\"\r\n"
+
+	doc, err := ParseDocument(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.String() != text {
+		t.Fatal("round trip failed:", doc.String())
+	}
+
+	doc.Set("default", "a", 2)
+	doc.Set("default", "b", "it's")
+	doc.Set("default", "c", true)
+	doc.Set("default", "d", []string{"x y", "z"})
+	doc.Set("section", "key2", "on")
+	doc.Set("new", "e", 1.5)
+	doc.Delete("section", "key")
+
+	expected := "# server config\r\na = 2 # the answer\r\nb='it\\'s'\r\nc = true\r\nd = \"x y\"\r\nd = z\r\n\r\n# section comment\r\n[section]\r\n  key2=\"on\"\r\n\r\n[new]\r\ne = 1.5\r\n"
+	if doc.String() != expected {
+		t.Fatalf("unexpected output: %q", doc.String())
+	}
+
+	cf, err := doc.Conf()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cf.GetInt("default", "a", 0) != 2 || cf.GetString("default", "b", "") != "it's" ||
+		!cf.GetBool("default", "c", false) || len(cf.GetArray("default", "d")) != 2 ||
+		cf.GetString("section", "key2", "") != "on" || cf.HasSection("section") && cf.GetString("section", "key", "") != "" ||
+		cf.GetFloat("new", "e", 0) != 1.5 {
+		t.Fatal("unexpected values:", doc.String())
+	}
+
+	doc, _ = ParseDocument("# only comments\n[s]\nk=v\n")
+	doc.Set("default", "x", "y")
+	if doc.String() != "x = y\n# only comments\n[s]\nk=v\n" {
+		t.Fatalf("unexpected output: %q", doc.String())
+	}
+}
+
+func TestDocumentFormat(t *testing.T) {
+	doc, err := ParseDocument(`
+
+  a=1   # one
+b   =  'x y'
+	c
+
+
+# about s
+[ s :default ] # child
+k=[1,  2]
+long = a \
+  b
+d = """
+  keep
+"""
+
+
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc.Format()
+	expected := "a = 1 # one\nb = 'x y'\nc\n\n# about s\n[s : default] # child\nk = [1,  2]\nlong = a \\\n  b\nd = \"\"\"\n  keep\n\"\"\"\n"
+	if doc.String() != expected {
+		t.Fatalf("unexpected output: %q", doc.String())
+	}
+
+	cf, err := doc.Conf()
+	if err != nil || cf.GetInt("s", "a", 0) != 1 || cf.GetString("s", "long", "") != "ab" || len(cf.GetArray("s", "k")) != 2 {
+		t.Fatal(err)
+	}
+
+	if doc.Format(); doc.String() != expected {
+		t.Fatalf("not idempotent: %q", doc.String())
+	}
+}
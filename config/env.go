@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// expandEnv returns the value of "VAR" or "VAR:-default", the default is used when VAR is unset or empty
+func expandEnv(expr string) string {
+	name, def := expr, ""
+	if i := strings.Index(expr, ":-"); i >= 0 {
+		name, def = expr[:i], expr[i+2:]
+	}
+
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
+
+// EnvName returns the name of the environment variable overriding key in section:
+// PREFIX_SECTION_KEY, or PREFIX_KEY for the default section. Letters are upper-cased
+// and other characters which are not digits become '_'.
+func EnvName(prefix, section, key string) string {
+	name := key
+	if section != "default" {
+		name = section + "_" + key
+	}
+
+	if prefix != "" {
+		name = prefix + "_" + name
+	}
+
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// ApplyEnv overrides values with the environment variables named by EnvName, values are
+// converted the same way as in ParseConf. Only keys already in the config can be overridden.
+func (c *conf_t) ApplyEnv(prefix string) {
+	for name, sec := range c.sections {
+		for k := range sec.values {
+			if text, ok := os.LookupEnv(EnvName(prefix, name, k)); ok {
+				v, raw := parseValue(text)
+				sec.set(k, v, []string{raw}, false)
+			}
+		}
+	}
+}
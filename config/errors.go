@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrCode identifies the kind of a ConfError
+type ErrCode int
+
+const (
+	ErrUnclosedSection ErrCode = iota + 1
+	ErrUnexpectedQuote
+	ErrUnclosedQuote
+	ErrUnexpectedEqual
+	ErrTrailingBackslash
+	ErrUnclosedVariable
+	ErrUnclosedBracket
+	ErrBadInline
+	ErrBadParent
+)
+
+var errMessages = map[ErrCode]string{
+	ErrUnclosedSection:   "section is not closed by ']'",
+	ErrUnexpectedQuote:   "unexpected quote inside a quoted string",
+	ErrUnclosedQuote:     "quote is not closed",
+	ErrUnexpectedEqual:   "unexpected '=' in value, quote the value if it is intended",
+	ErrTrailingBackslash: "backslash at the end of value",
+	ErrUnclosedVariable:  "variable is not closed by '}'",
+	ErrUnclosedBracket:   "inline array or table is not closed",
+	ErrBadInline:         "malformed inline array or table",
+	ErrBadParent:         "parent section doesn't exist or inherits the section itself",
+}
+
+// ConfError is an error found at a position of the config text
+type ConfError struct {
+	line   int // 1-based
+	column int // 1-based, in bytes
+	code   ErrCode
+	text   string // the unexpected text
+	source string // the line containing the error
+}
+
+func newError(code ErrCode, ln int, line string, idx int, text string) *ConfError {
+	return &ConfError{line: ln + 1, column: idx + 1, code: code, text: text, source: line}
+}
+
+// Line returns the 1-based line number of the error
+func (e *ConfError) Line() int { return e.line }
+
+// Column returns the 1-based column (in bytes) of the error
+func (e *ConfError) Column() int { return e.column }
+
+// Code returns the kind of the error
+func (e *ConfError) Code() ErrCode { return e.code }
+
+// Excerpt returns the offending line followed by a line with a caret pointing at the error
+func (e *ConfError) Excerpt() string {
+	caret := &strings.Builder{}
+	for i, r := range e.source {
+		if i >= e.column-1 {
+			break
+		}
+
+		if r == '\t' {
+			caret.WriteByte('\t')
+		} else {
+			caret.WriteByte(' ')
+		}
+	}
+	caret.WriteByte('^')
+	return e.source + "\n" + caret.String()
+}
+
+func (e *ConfError) Error() string {
+	return fmt.Sprintf("line %d:%d: unexpected %s: %s (E%d)\n%s", e.line, e.column, e.text, errMessages[e.code], e.code, e.Excerpt())
+}
+
+// ConfErrors contains all errors found in a config text, ordered by their positions
+type ConfErrors []*ConfError
+
+func (e ConfErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap exposes every error to errors.Is and errors.As, errors.As finds the first one matching the target
+func (e ConfErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
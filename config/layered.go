@@ -0,0 +1,165 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// Layered merges configs from multiple sources, layers added later take precedence over earlier ones.
+// Section inheritance is resolved after merging, so a section can inherit a parent defined in another layer.
+type Layered struct {
+	layers  []layer
+	merged  *conf_t
+	sources map[[2]string]string
+}
+
+type layer struct {
+	name string
+	conf *conf_t
+}
+
+// NewLayered creates an empty Layered
+func NewLayered() *Layered {
+	return &Layered{}
+}
+
+// Add adds a parsed config as a layer
+func (l *Layered) Add(name string, c *conf_t) *Layered {
+	l.layers = append(l.layers, layer{name, c})
+	l.merged = nil
+	return l
+}
+
+// AddFile parses a config file and adds it as a layer named by its path,
+// sections in it can inherit parents defined in other layers
+func (l *Layered) AddFile(path string) error {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	c, err := parse(string(buf), false)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	l.Add(path, c)
+	return nil
+}
+
+// AddMap adds values as a layer, they are used as they are and should be of the types ParseConf produces
+func (l *Layered) AddMap(name string, m map[string]map[string]interface{}) *Layered {
+	c := newConf()
+	for section, kvs := range m {
+		sec := c.section(section)
+		for k, v := range kvs {
+			raw := []string{toRaw(v)}
+			if arr, ok := v.([]interface{}); ok {
+				raw = raw[:0]
+				for _, v := range arr {
+					raw = append(raw, toRaw(v))
+				}
+			}
+			sec.set(k, v, raw, false)
+		}
+	}
+	return l.Add(name, c)
+}
+
+// AddEnv adds the environment variables named by EnvName as a layer named "env". Since names
+// are derived from keys, only keys defined by the layers before it can be overridden.
+func (l *Layered) AddEnv(prefix string) *Layered {
+	c := newConf()
+	for _, ly := range l.layers {
+		for name, sec := range ly.conf.sections {
+			for k := range sec.values {
+				if sec.inherited[k] {
+					continue
+				}
+
+				if text, ok := os.LookupEnv(EnvName(prefix, name, k)); ok {
+					v, raw := parseValue(text)
+					c.section(name).set(k, v, []string{raw}, false)
+				}
+			}
+		}
+	}
+	return l.Add("env", c)
+}
+
+// AddFlags adds flags which have been set on the command line as a layer named "flags",
+// a flag named "section.key" sets key in section and other flags set keys in the default section.
+func (l *Layered) AddFlags(fs *flag.FlagSet) *Layered {
+	c := newConf()
+	fs.Visit(func(f *flag.Flag) {
+		section, key := splitPath(f.Name)
+		v, raw := parseValue(f.Value.String())
+		c.section(section).set(key, v, []string{raw}, false)
+	})
+	return l.Add("flags", c)
+}
+
+// Conf returns the merged config
+func (l *Layered) Conf() (*conf_t, error) {
+	if l.merged != nil {
+		return l.merged, nil
+	}
+
+	merged, sources := newConf(), map[[2]string]string{}
+	for _, ly := range l.layers {
+		for _, name := range ly.conf.order {
+			sec, msec := ly.conf.sections[name], merged.section(name)
+			if sec.parent != "" {
+				msec.parent = sec.parent
+			}
+
+			for _, k := range sec.keys {
+				if !sec.inherited[k] {
+					msec.set(k, sec.values[k], sec.raw[k], false)
+					sources[[2]string{name, k}] = ly.name
+				}
+			}
+		}
+	}
+
+	if name, ok := merged.inherit(); !ok {
+		return nil, fmt.Errorf("section %s: parent %q doesn't exist or inherits the section itself", name, merged.sections[name].parent)
+	}
+
+	for name, sec := range merged.sections {
+		for k := range sec.inherited {
+			for p := sec.parent; ; p = merged.sections[p].parent {
+				if src, ok := sources[[2]string{p, k}]; ok {
+					sources[[2]string{name, k}] = src
+					break
+				}
+			}
+		}
+	}
+
+	l.merged, l.sources = merged, sources
+	return merged, nil
+}
+
+// Source returns the name of the layer which supplies the effective value of key in section,
+// empty if no layer has it
+func (l *Layered) Source(section, key string) string {
+	if _, err := l.Conf(); err != nil {
+		return ""
+	}
+	return l.sources[[2]string{section, key}]
+}
+
+// toRaw formats values of types ParseConf produces back into text
+func toRaw(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	s, err := formatValue(v, 0)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return s
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLayered(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "base.conf")
+	os.WriteFile(path, []byte(`name = app
+	[default]
+	port = 80
+	debug = on
+	[server]
+	host = localhost
+	workers = 4
+	[prod : server]
+	workers = 16`), 0644)
+
+	cf, err := ParseConf(`[a : b]
+	[b : a]`)
+	if err == nil || firstError(err).Code() != ErrBadParent || firstError(err).Line() != 1 {
+		t.Fatal(err)
+	}
+
+	l := NewLayered()
+	if err := l.AddFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	override := filepath.Join(t.TempDir(), "override.conf")
+	os.WriteFile(override, []byte(`[server]
+	host = example.com
+	[staging : prod]
+	debug = off`), 0644)
+	if err := l.AddFile(override); err != nil {
+		t.Fatal(err)
+	}
+	l.AddMap("defaults", map[string]map[string]interface{}{"default": {"port": 8080.0, "tags": []interface{}{"a", 1.0}}})
+
+	t.Setenv("APP_PROD_WORKERS", "32")
+	l.AddEnv("APP")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("server.host", "", "")
+	fs.Bool("debug", true, "")
+	fs.Parse([]string{"-server.host=flag.com"})
+	l.AddFlags(fs)
+
+	if cf, err = l.Conf(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		section, key string
+		value        interface{}
+		source       string
+	}{
+		{"default", "name", "app", path},
+		{"default", "port", 8080.0, "defaults"},
+		{"default", "debug", true, path},
+		{"server", "host", "flag.com", "flags"},
+		{"prod", "host", "flag.com", "flags"},
+		{"prod", "workers", 32.0, "env"},
+		{"staging", "workers", 32.0, "env"},
+		{"staging", "debug", false, override},
+		{"server", "workers", 4.0, path},
+	} {
+		v, _ := cf.get(c.section, c.key)
+		if v != c.value || l.Source(c.section, c.key) != c.source {
+			t.Fatal(c, v, l.Source(c.section, c.key))
+		}
+	}
+
+	if raw := cf.sections["default"].raw["tags"]; len(raw) != 2 || raw[1] != "1" || cf.Parent("staging") != "prod" {
+		t.Fatal(raw)
+	}
+}
@@ -0,0 +1,480 @@
+package config
+
+import (
+	"bytes"
+	"strings"
+)
+
+// logicalLine is a line of config text, it spans multiple physical lines
+// if it contains a multi-line string or ends with a backslash
+type logicalLine struct {
+	raw    string // the original text, including line breaks inside it
+	text   string // the text to scan, with continuations joined
+	ln     int    // 0-based number of the first physical line
+	cr     bool   // the last physical line ended with "\r\n"
+	pieces []linePiece
+}
+
+type linePiece struct {
+	start  int // offset of the piece in text
+	column int // offset of the piece in its physical line
+	line   string
+}
+
+// error creates a ConfError at position idx of the text
+func (ll *logicalLine) error(code ErrCode, idx int, text string) *ConfError {
+	i := len(ll.pieces) - 1
+	for i > 0 && ll.pieces[i].start > idx {
+		i--
+	}
+	p := ll.pieces[i]
+	return newError(code, ll.ln+i, p.line, idx-p.start+p.column, text)
+}
+
+// splitLines splits str into logical lines
+func splitLines(str string) []*logicalLine {
+	var res []*logicalLine
+	phys := strings.Split(str, "\n")
+
+	for i := 0; i < len(phys); {
+		ll := &logicalLine{ln: i}
+		text, quote := &strings.Builder{}, ""
+
+		for {
+			line := strings.TrimSuffix(phys[i], "\r")
+			ll.cr = len(line) < len(phys[i])
+			i++
+
+			column := 0
+			if len(ll.pieces) > 0 && len(quote) != 3 {
+				column = len(line) - len(strings.TrimLeft(line, " \t"))
+			}
+
+			ll.pieces = append(ll.pieces, linePiece{text.Len(), column, line})
+			seg := line[column:]
+
+			var cont bool
+			quote, cont = scanQuotes(seg, quote)
+			if cont && i < len(phys) {
+				seg = strings.TrimRight(seg, " \t")
+				text.WriteString(seg[:len(seg)-1])
+				continue
+			}
+
+			text.WriteString(seg)
+			if len(quote) == 3 && i < len(phys) {
+				text.WriteByte('\n')
+				continue
+			}
+			break
+		}
+
+		ll.raw = strings.TrimSuffix(strings.Join(phys[ll.ln:i], "\n"), "\r")
+		ll.text = text.String()
+		res = append(res, ll)
+	}
+	return res
+}
+
+// scanQuotes returns the quote still open at the end of seg, and whether seg ends with
+// a backslash continuing the line
+func scanQuotes(seg string, quote string) (string, bool) {
+	for i := 0; i < len(seg); i++ {
+		c := seg[i]
+		switch {
+		case len(quote) == 3:
+			if strings.HasPrefix(seg[i:], quote) {
+				i, quote = i+2, ""
+			}
+		case quote != "":
+			if c == quote[0] && (i == 0 || seg[i-1] != '\\') {
+				quote = ""
+			}
+		case c == '#':
+			return quote, false
+		case c == '\'' || c == '"':
+			if i > 0 && seg[i-1] == '\\' {
+				continue
+			}
+
+			if quote = seg[i : i+1]; strings.HasPrefix(seg[i:], strings.Repeat(quote, 3)) {
+				i, quote = i+2, strings.Repeat(quote, 3)
+			}
+		}
+	}
+
+	if len(quote) == 3 {
+		return quote, false
+	}
+
+	trimmed := strings.TrimRight(seg, " \t")
+	n := len(trimmed) - len(strings.TrimRight(trimmed, "\\"))
+	return quote, n%2 == 1
+}
+
+// lineScan is the result of scanning a logical line, all positions are byte offsets in the text
+type lineScan struct {
+	key, value []byte
+	vpos       []int // position of each byte of value
+	section    string
+	parent     string // "base" of [section : base]
+	header     bool
+	eq         int // position of '=', -1 if there is none
+	start, end int // position of the raw value
+
+	// bracket is '[' or '{' if the value is an inline array or table, which is parsed into inline and raw
+	bracket byte
+	inline  interface{}
+	raw     []string
+}
+
+// scanLine scans a logical line and parses its inline array or table. A value starting with '{' which is
+// not a valid inline table is a plain string as it was before inline tables are supported, e.g. "{name}".
+func scanLine(ll *logicalLine) (lineScan, *ConfError) {
+	s, err := scanLineMode(ll, true)
+	if s.bracket == 0 {
+		return s, err
+	}
+
+	if err == nil {
+		s.inline, s.raw, err = (&inlineParser{v: s.value, vpos: s.vpos, ll: ll}).parse()
+	}
+	if err != nil && s.bracket == '{' {
+		return scanLineMode(ll, false)
+	}
+	return s, err
+}
+
+// scanLineMode scans a logical line, values starting with brackets are inline arrays or tables if inline is true
+func scanLineMode(ll *logicalLine, inline bool) (s lineScan, err *ConfError) {
+	line := ll.text
+	key, value := &bytes.Buffer{}, &bytes.Buffer{}
+	idx := len(line) - len(strings.TrimLeft(line, " \t\v\f"))
+	p, quote, quoteStart := key, byte(0), 0
+	s.eq, s.start, s.end = -1, -1, -1
+
+	write := func(c byte) {
+		if p == value {
+			if value.Len() == 0 {
+				s.start = idx
+			}
+			s.end = idx + 1
+			s.vpos = append(s.vpos, idx)
+		}
+		p.WriteByte(c)
+	}
+
+	// writeTo writes everything before end verbatim
+	writeTo := func(end int) {
+		for ; idx < end; idx++ {
+			write(line[idx])
+		}
+		idx--
+	}
+
+L:
+	for idx < len(line) {
+		c := line[idx]
+
+		switch c {
+		case '[', '{':
+			if inline && quote == 0 && p == value && value.Len() == 0 {
+				// inline array or table
+				s.bracket = c
+				e := matchBracket(line, idx)
+				if e == -1 {
+					return s, ll.error(ErrUnclosedBracket, idx, string(c))
+				}
+				writeTo(e + 1)
+			} else if quote == 0 && c == '[' {
+				if e := strings.Index(line[idx:], "]"); e > 0 {
+					s.section, s.header = line[idx+1:idx+e], true
+					if i := strings.IndexByte(s.section, ':'); i >= 0 {
+						s.section, s.parent = s.section[:i], strings.TrimSpace(s.section[i+1:])
+					}
+					s.section = strings.TrimSpace(s.section)
+					break L
+				} else {
+					return s, ll.error(ErrUnclosedSection, idx, "[")
+				}
+			} else {
+				write(c)
+			}
+		case ' ', '\t':
+			if quote != 0 {
+				write(c)
+			}
+		case '\'', '"':
+			if idx > 0 && line[idx-1] == '\\' {
+				// escape
+			} else if quote == 0 && strings.HasPrefix(line[idx:], strings.Repeat(string(c), 3)) {
+				e := strings.Index(line[idx+3:], strings.Repeat(string(c), 3))
+				if e == -1 {
+					return s, ll.error(ErrUnclosedQuote, idx, line[idx:idx+3])
+				}
+				writeTo(idx + e + 6)
+				break
+			} else if quote == 0 {
+				quote, quoteStart = c, idx
+			} else if quote == c {
+				quote = 0
+			} else {
+				return s, ll.error(ErrUnexpectedQuote, idx, string(c))
+			}
+
+			write(c)
+		case '#':
+			if quote == 0 {
+				break L
+			} else {
+				write(c)
+			}
+		case '=':
+			if quote != 0 {
+				write(c)
+			} else if p != value {
+				p = value
+				s.eq, s.start, s.end = idx, idx+1, idx+1
+			} else {
+				return s, ll.error(ErrUnexpectedEqual, idx, "=")
+			}
+		default:
+			write(c)
+		}
+
+		idx++
+	}
+
+	if quote != 0 {
+		return s, ll.error(ErrUnclosedQuote, quoteStart, string(quote))
+	}
+
+	s.key, s.value = key.Bytes(), value.Bytes()
+	return s, nil
+}
+
+// matchBracket returns the position of the bracket closing the one at idx, or -1
+func matchBracket(line string, idx int) int {
+	var stack []byte
+	quote := byte(0)
+
+	for i := idx; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote && line[i-1] != '\\' {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			stack = append(stack, ']')
+		case c == '{':
+			stack = append(stack, '}')
+		case c == ']' || c == '}':
+			if stack[len(stack)-1] != c {
+				return -1
+			}
+
+			if stack = stack[:len(stack)-1]; len(stack) == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// decode unescapes a value and expands environment variables in it, triple quotes are replaced by
+// single ones. vpos is the position of each byte of v in the text of ll.
+func decode(v []byte, vpos []int, ll *logicalLine) (string, *ConfError) {
+	buf := &bytes.Buffer{}
+	idx := 0
+	literal := len(v) > 0 && v[0] == '\''
+
+	if len(v) >= 6 && (bytes.HasPrefix(v, []byte(`"""`)) || bytes.HasPrefix(v, []byte(`'''`))) {
+		// a newline right after the opening quotes is not part of the string
+		buf.WriteByte(v[0])
+		idx, v = 3, v[:len(v)-2]
+		if bytes.HasPrefix(v[idx:], []byte("\n")) {
+			idx++
+		}
+	}
+
+	for idx < len(v) {
+		if v[idx] == '$' && !literal && idx < len(v)-1 && v[idx+1] == '{' {
+			e := bytes.IndexByte(v[idx:], '}')
+			if e == -1 {
+				return "", ll.error(ErrUnclosedVariable, vpos[idx], "${")
+			}
+
+			buf.WriteString(expandEnv(string(v[idx+2 : idx+e])))
+			idx += e + 1
+		} else if v[idx] == '\\' {
+			if idx == len(v)-1 {
+				return "", ll.error(ErrTrailingBackslash, vpos[idx], "\\")
+			}
+
+			switch v[idx+1] {
+			case 'n':
+				buf.WriteByte('\n')
+			case 'r':
+				buf.WriteByte('\r')
+			case 't':
+				buf.WriteByte('\t')
+			default:
+				buf.WriteByte(v[idx+1])
+			}
+			idx += 2
+		} else {
+			buf.WriteByte(v[idx])
+			idx++
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// inlineParser parses inline arrays like [a, b, c] and inline tables like {k = v, k2 = [1, 2]}
+type inlineParser struct {
+	v    []byte
+	vpos []int
+	idx  int
+	ll   *logicalLine
+	raws []string // raw texts of the elements of the last parsed array
+}
+
+// parse parses the whole value, for arrays raw contains the text of each element
+func (p *inlineParser) parse() (interface{}, []string, *ConfError) {
+	v, raw, err := p.value()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if p.skip(); p.idx < len(p.v) {
+		return nil, nil, p.error()
+	}
+
+	if _, ok := v.([]interface{}); ok {
+		return v, p.raws, nil
+	}
+	return v, []string{raw}, nil
+}
+
+func (p *inlineParser) value() (v interface{}, raw string, err *ConfError) {
+	if p.skip(); p.idx >= len(p.v) {
+		return nil, "", p.error()
+	}
+
+	start := p.idx
+
+	switch p.v[p.idx] {
+	case '[':
+		arr, raws := []interface{}{}, []string{}
+		p.idx++
+
+		for {
+			if p.skip(); p.idx < len(p.v) && p.v[p.idx] == ']' {
+				p.idx++
+				p.raws = raws
+				return arr, string(p.v[start:p.idx]), nil
+			}
+
+			v, raw, err := p.value()
+			if err != nil {
+				return nil, "", err
+			}
+			arr, raws = append(arr, v), append(raws, raw)
+
+			if !p.next(']') {
+				return nil, "", p.error()
+			}
+		}
+	case '{':
+		m := map[string]interface{}{}
+		p.idx++
+
+		for {
+			if p.skip(); p.idx < len(p.v) && p.v[p.idx] == '}' {
+				p.idx++
+				return m, string(p.v[start:p.idx]), nil
+			}
+
+			k := p.idx
+			for p.idx < len(p.v) && strings.IndexByte(" \t\r\n=,{}[]'\"", p.v[p.idx]) == -1 {
+				p.idx++
+			}
+			key := string(p.v[k:p.idx])
+
+			if p.skip(); key == "" || p.idx >= len(p.v) || p.v[p.idx] != '=' {
+				return nil, "", p.error()
+			}
+			p.idx++
+
+			v, _, err := p.value()
+			if err != nil {
+				return nil, "", err
+			}
+			m[key] = v
+
+			if !p.next('}') {
+				return nil, "", p.error()
+			}
+		}
+	case '\'', '"':
+		q := p.v[p.idx]
+		for p.idx++; p.idx < len(p.v); p.idx++ {
+			if p.v[p.idx] == q && p.v[p.idx-1] != '\\' {
+				break
+			}
+		}
+
+		if p.idx >= len(p.v) {
+			return nil, "", p.error()
+		}
+		p.idx++
+	case ']', '}', ',', '=':
+		return nil, "", p.error()
+	default:
+		for p.idx < len(p.v) && strings.IndexByte(" \t\r\n,{}[]=", p.v[p.idx]) == -1 {
+			p.idx++
+		}
+	}
+
+	s, err := decode(p.v[start:p.idx], p.vpos[start:p.idx], p.ll)
+	if err != nil {
+		return nil, "", err
+	}
+
+	v, raw = parseValue(s)
+	return v, raw, nil
+}
+
+func (p *inlineParser) skip() {
+	for p.idx < len(p.v) && strings.IndexByte(" \t\r\n", p.v[p.idx]) >= 0 {
+		p.idx++
+	}
+}
+
+// next skips a comma, returns false if neither a comma nor the closing bracket follows
+func (p *inlineParser) next(closing byte) bool {
+	if p.skip(); p.idx >= len(p.v) {
+		return false
+	}
+
+	switch p.v[p.idx] {
+	case ',':
+		p.idx++
+		return true
+	case closing:
+		return true
+	}
+	return false
+}
+
+func (p *inlineParser) error() *ConfError {
+	i := p.idx
+	if i >= len(p.v) {
+		i = len(p.v) - 1
+	}
+	return p.ll.error(ErrBadInline, p.vpos[i], string(p.v[i]))
+}
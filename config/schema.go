@@ -0,0 +1,366 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Type is the expected type of a value
+type Type int
+
+const (
+	TypeAny Type = iota
+	TypeString
+	TypeInt
+	TypeFloat
+	TypeBool
+	TypeArray
+)
+
+var typeNames = [...]string{"any", "string", "int", "float", "bool", "array"}
+
+func (t Type) String() string {
+	if t >= 0 && int(t) < len(typeNames) {
+		return typeNames[t]
+	}
+	return fmt.Sprintf("Type(%d)", int(t))
+}
+
+func (t Type) match(v interface{}) bool {
+	ok := true
+	switch t {
+	case TypeString:
+		_, ok = v.(string)
+	case TypeInt:
+		var f float64
+		f, ok = v.(float64)
+		ok = ok && f == math.Trunc(f)
+	case TypeFloat:
+		_, ok = v.(float64)
+	case TypeBool:
+		_, ok = v.(bool)
+	}
+	return ok
+}
+
+// Rule constrains the value of a key
+type Rule struct {
+	Section  string // empty means "default"
+	Key      string
+	Type     Type
+	Required bool
+	Min, Max *float64 // range of numbers, or range of the length of strings and arrays
+	Enum     []string // allowed values, compared with fmt.Sprint(value)
+	Pattern  string   // regexp that strings must match
+}
+
+// Schema is a set of rules, keys not covered by any rule are reported as unknown unless AllowUnknown is set
+type Schema struct {
+	Rules        []Rule
+	AllowUnknown bool
+}
+
+// Violation is a key that doesn't satisfy the schema
+type Violation struct {
+	Section string
+	Key     string
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("[%s] %s: %s", v.Section, v.Key, v.Message)
+}
+
+// Violations contains all violations found by Schema.Validate, sorted by section and key
+type Violations []Violation
+
+func (v Violations) Error() string {
+	msgs := make([]string, len(v))
+	for i, v := range v {
+		msgs[i] = v.String()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Validate checks the config against the schema and returns Violations if there are any
+func (s *Schema) Validate(c *conf_t) error {
+	var res Violations
+	known := map[[2]string]bool{}
+
+	for _, r := range s.Rules {
+		sec := r.Section
+		if sec == "" {
+			sec = "default"
+		}
+		known[[2]string{sec, r.Key}] = true
+
+		v, ok := c.get(sec, r.Key)
+		if !ok {
+			if r.Required {
+				res = append(res, Violation{sec, r.Key, "required key is missing"})
+			}
+			continue
+		}
+
+		if msg := r.check(v); msg != "" {
+			res = append(res, Violation{sec, r.Key, msg})
+		}
+	}
+
+	if !s.AllowUnknown {
+		for sec, kvs := range c.sections {
+			for k := range kvs.values {
+				if !known[[2]string{sec, k}] {
+					res = append(res, Violation{sec, k, "unknown key"})
+				}
+			}
+		}
+	}
+
+	if len(res) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Section != res[j].Section {
+			return res[i].Section < res[j].Section
+		}
+		return res[i].Key < res[j].Key
+	})
+	return res
+}
+
+func (r *Rule) check(v interface{}) string {
+	arr, isArray := v.([]interface{})
+
+	if r.Type == TypeArray {
+		if !isArray {
+			arr = []interface{}{v}
+		}
+
+		if msg := r.checkRange(float64(len(arr)), "length"); msg != "" {
+			return msg
+		}
+
+		for i, v := range arr {
+			if msg := r.checkValue(v); msg != "" {
+				return fmt.Sprintf("value #%d: %s", i, msg)
+			}
+		}
+		return ""
+	}
+
+	if isArray && r.Type != TypeAny {
+		return fmt.Sprintf("expect a single %v, got %d values", r.Type, len(arr))
+	}
+
+	// hex, octal, binary and underscored integers are strings, see GetInt64
+	if s, ok := v.(string); ok && (r.Type == TypeInt || r.Type == TypeFloat) {
+		if n, err := parseInt(s); err == nil {
+			v = float64(n)
+		}
+	}
+
+	if !r.Type.match(v) {
+		return fmt.Sprintf("expect %v, got %v", r.Type, v)
+	}
+
+	var msg string
+	switch v := v.(type) {
+	case []interface{}:
+		msg = r.checkRange(float64(len(v)), "length")
+	case string:
+		msg = r.checkRange(float64(len(v)), "length")
+	case float64:
+		msg = r.checkRange(v, "value")
+	}
+
+	if msg != "" {
+		return msg
+	}
+	return r.checkValue(v)
+}
+
+// checkValue checks enum and pattern
+func (r *Rule) checkValue(v interface{}) string {
+	if len(r.Enum) > 0 {
+		found, str := false, fmt.Sprint(v)
+		for _, e := range r.Enum {
+			if e == str {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Sprintf("%v is not one of %s", v, strings.Join(r.Enum, ", "))
+		}
+	}
+
+	if s, ok := v.(string); ok && r.Pattern != "" {
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return fmt.Sprintf("invalid pattern: %v", err)
+		}
+
+		if !re.MatchString(s) {
+			return fmt.Sprintf("%q doesn't match %s", s, r.Pattern)
+		}
+	}
+	return ""
+}
+
+func (r *Rule) checkRange(n float64, what string) string {
+	if r.Min != nil && n < *r.Min {
+		return fmt.Sprintf("%s %v is less than %v", what, n, *r.Min)
+	}
+	if r.Max != nil && n > *r.Max {
+		return fmt.Sprintf("%s %v is greater than %v", what, n, *r.Max)
+	}
+	return ""
+}
+
+// SchemaOf builds a schema from the tags of a struct (or a pointer to it), for example:
+//
+//	type Server struct {
+//		Host string   `conf:"server.host,required,pattern=^[a-z.]+$"`
+//		Port int      `conf:"server.port,min=1,max=65535"`
+//		Mode string   `conf:"mode,enum=dev|prod"`
+//		Tags []string `conf:"server.tag,max=8"`
+//	}
+//
+// The part before the last dot is the section, the type of the rule is derived from the type of the field.
+// Options are separated by commas, pattern takes the rest of the tag so it must be the last one.
+func SchemaOf(v interface{}) (*Schema, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expect a struct, got %v", t)
+	}
+
+	s := &Schema{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("conf")
+		if !ok || tag == "-" {
+			continue
+		}
+
+		r := Rule{}
+		name, opts, _ := strings.Cut(tag, ",")
+		r.Section, r.Key = splitPath(name)
+
+		switch f.Type.Kind() {
+		case reflect.String:
+			r.Type = TypeString
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			r.Type = TypeInt
+		case reflect.Float32, reflect.Float64:
+			r.Type = TypeFloat
+		case reflect.Bool:
+			r.Type = TypeBool
+		case reflect.Slice, reflect.Array:
+			r.Type = TypeArray
+		}
+
+		if err := r.parseOptions(opts); err != nil {
+			return nil, fmt.Errorf("field %s: %v", f.Name, err)
+		}
+
+		s.Rules = append(s.Rules, r)
+	}
+	return s, nil
+}
+
+// parseOptions parses options separated by commas, pattern takes the rest so it must be the last one
+func (r *Rule) parseOptions(opts string) error {
+	for opts != "" {
+		var opt string
+		if strings.HasPrefix(opts, "pattern=") {
+			opt, opts = opts, ""
+		} else {
+			opt, opts, _ = strings.Cut(opts, ",")
+		}
+
+		k, v, _ := strings.Cut(opt, "=")
+		switch k {
+		case "required":
+			r.Required = true
+		case "min", "max":
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %q", k, v)
+			}
+
+			if k == "min" {
+				r.Min = &n
+			} else {
+				r.Max = &n
+			}
+		case "enum":
+			r.Enum = strings.Split(v, "|")
+		case "pattern":
+			if _, err := regexp.Compile(v); err != nil {
+				return err
+			}
+			r.Pattern = v
+		default:
+			return fmt.Errorf("unknown option %q", opt)
+		}
+	}
+	return nil
+}
+
+// ParseSchema parses a schema written as a config file, each key is described by a type
+// followed by the options of SchemaOf, for example:
+//
+//	mode = 'string,enum=dev|prod'
+//	[server]
+//	host = 'string,required,pattern=^[a-z]+\\.com$'
+//	port = 'int,min=1,max=65535'
+//
+// Descriptions containing '=' must be quoted, backslashes are escapes in both quotes so patterns need '\\'.
+func ParseSchema(str string) (*Schema, error) {
+	c, err := ParseConf(str)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Schema{}
+	for _, name := range c.order {
+		sec := c.sections[name]
+		for _, k := range sec.keys {
+			desc, ok := sec.values[k].(string)
+			if !ok {
+				return nil, fmt.Errorf("[%s] %s: description must be a string", name, k)
+			}
+
+			r := Rule{Section: name, Key: k, Type: -1}
+			typ, opts, _ := strings.Cut(desc, ",")
+			for t, n := range typeNames {
+				if n == typ {
+					r.Type = Type(t)
+				}
+			}
+
+			if r.Type < 0 {
+				return nil, fmt.Errorf("[%s] %s: unknown type %q", name, k, typ)
+			}
+
+			if err := r.parseOptions(opts); err != nil {
+				return nil, fmt.Errorf("[%s] %s: %v", name, k, err)
+			}
+			s.Rules = append(s.Rules, r)
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestSchema(t *testing.T) {
+	type server struct {
+		Host  string   `conf:"server.host,required,pattern=^[a-z.]+$"`
+		Port  int      `conf:"server.port,min=1,max=65535"`
+		Mode  string   `conf:"mode,enum=dev|prod"`
+		Tags  []string `conf:"server.tag,max=2"`
+		Debug bool     `conf:"debug"`
+		Name  string   `conf:"server.name,required"`
+	}
+
+	s, err := SchemaOf(&server{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cf, _ := ParseConf(`mode = test
+	debug = 1
+	[server]
+	host = Example.com
+	port = 80.5
+	tag = a
+	tag = b
+	tag = c
+	extra = 1`)
+
+	err = s.Validate(cf)
+	v, ok := err.(Violations)
+	if !ok || len(v) != 7 {
+		t.Fatal(err)
+	}
+
+	for i, key := range []string{"debug", "mode", "extra", "host", "name", "port", "tag"} {
+		if v[i].Key != key {
+			t.Fatal(err)
+		}
+	}
+
+	cf, _ = ParseConf(`mode = prod
+	[server]
+	host = example.com
+	port = 8080
+	name = "web 1"
+	tag = a`)
+
+	if err := s.Validate(cf); err != nil {
+		t.Fatal(err)
+	}
+
+	if TypeArray.String() != "array" || Type(9).String() != "Type(9)" {
+		t.Fatal(Type(9))
+	}
+}
+
+func TestParseSchema(t *testing.T) {
+	s, err := ParseSchema(`mode = 'string,enum=dev|prod'
+	[server]
+	host = 'string,required,pattern=^[a-z.]+\\.com$'
+	port = 'int,min=1,max=65535'
+	debug = bool`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.Rules) != 4 || s.Rules[1].Section != "server" || s.Rules[2].Type != TypeInt || *s.Rules[2].Max != 65535 {
+		t.Fatal(s.Rules)
+	}
+
+	cf, _ := ParseConf(`mode = test
+	[server]
+	host = example.org
+	port = 0`)
+	if v, ok := s.Validate(cf).(Violations); !ok || len(v) != 3 {
+		t.Fatal(v)
+	}
+
+	// the escaped dot in the pattern only matches a dot
+	cf, _ = ParseConf("[server]\nhost = x.com\nport = 80")
+	if err := s.Validate(cf); err != nil {
+		t.Fatal(err)
+	}
+	cf, _ = ParseConf("[server]\nhost = xacom\nport = 80")
+	if v, ok := s.Validate(cf).(Violations); !ok || len(v) != 1 {
+		t.Fatal(v)
+	}
+
+	// hex integers are strings but valid ints
+	cf, _ = ParseConf("[server]\nhost = example.com\nport = 0x50")
+	if err := s.Validate(cf); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, text := range []string{"a = number", "a = 'int,min=x'", "a = 1", "a = 'int,foo'"} {
+		if _, err := ParseSchema(text); err == nil {
+			t.Fatal(text)
+		}
+	}
+}
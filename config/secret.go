@@ -0,0 +1,208 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// secretPrefix marks a value encrypted by Encrypt, it is followed by the base64 (URL alphabet,
+// no padding) of the nonce and the AES-GCM sealed text, so it can be written unquoted
+const secretPrefix = "enc:"
+
+// KeyProvider supplies the AES key (16, 24 or 32 bytes) used to encrypt and decrypt secret values
+type KeyProvider interface {
+	Key() ([]byte, error)
+}
+
+// KeyFunc adapts a function to KeyProvider
+type KeyFunc func() ([]byte, error)
+
+func (f KeyFunc) Key() ([]byte, error) { return f() }
+
+// FileKey reads the key from a file, which contains either the raw key or its base64
+func FileKey(path string) KeyProvider {
+	return KeyFunc(func() ([]byte, error) {
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if key, err := decodeKey(strings.TrimSpace(string(buf))); err == nil {
+			return key, nil
+		}
+
+		if !validKeySize(len(buf)) {
+			return nil, fmt.Errorf("%s: invalid key", path)
+		}
+		return buf, nil
+	})
+}
+
+// EnvKey reads the base64 of the key from an environment variable
+func EnvKey(name string) KeyProvider {
+	return KeyFunc(func() ([]byte, error) {
+		text, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, fmt.Errorf("environment variable %s is not set", name)
+		}
+
+		key, err := decodeKey(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		return key, nil
+	})
+}
+
+// GenerateKey returns the base64 of a random 256-bit key, suitable for FileKey and EnvKey
+func GenerateKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+func validKeySize(n int) bool {
+	return n == 16 || n == 24 || n == 32
+}
+
+func decodeKey(text string) ([]byte, error) {
+	key, err := decodeBase64(text)
+	if err != nil {
+		return nil, err
+	}
+
+	if !validKeySize(len(key)) {
+		return nil, fmt.Errorf("invalid key size: %d", len(key))
+	}
+	return key, nil
+}
+
+// decodeBase64 accepts both the standard and the URL alphabet, with or without padding
+func decodeBase64(text string) ([]byte, error) {
+	text = strings.TrimRight(text, "=")
+	text = strings.NewReplacer("+", "-", "/", "_").Replace(text)
+	return base64.RawURLEncoding.DecodeString(text)
+}
+
+func newGCM(kp KeyProvider) (cipher.AEAD, error) {
+	key, err := kp.Key()
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypt encrypts text with AES-GCM and returns "enc:<base64>", which can be put in config files as a value
+func Encrypt(kp KeyProvider, text string) (string, error) {
+	gcm, err := newGCM(kp)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(text), nil)
+	return secretPrefix + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+func decrypt(gcm cipher.AEAD, text string) (string, error) {
+	buf, err := decodeBase64(strings.TrimPrefix(text, secretPrefix))
+	if err != nil {
+		return "", err
+	}
+
+	if len(buf) < gcm.NonceSize() {
+		return "", fmt.Errorf("ciphertext too short")
+	}
+
+	plain, err := gcm.Open(nil, buf[:gcm.NonceSize()], buf[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// ParseEncryptedConf parses the config text and decrypts its secret values with the key, see Decrypt
+func ParseEncryptedConf(str string, kp KeyProvider) (*conf_t, error) {
+	c, err := ParseConf(str)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Decrypt(kp); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Decrypt replaces string values (including elements of arrays) starting with "enc:" by their decrypted text.
+// Secrets stay strings, e.g. "007" is not converted into a number, but GetInt64 and the like can parse them.
+// Configs reloaded by a Watcher are decrypted if WatchOptions.Key is set.
+func (c *conf_t) Decrypt(kp KeyProvider) error {
+	var gcm cipher.AEAD
+	for _, name := range c.order {
+		sec := c.sections[name]
+		for _, k := range sec.keys {
+			values, ok := sec.values[k].([]interface{})
+			if !ok {
+				values = []interface{}{sec.values[k]}
+			}
+
+			changed := false
+			raws := append([]string(nil), sec.raw[k]...)
+			if len(raws) != len(values) {
+				raws = make([]string, len(values))
+			}
+			for i, v := range values {
+				s, ok := v.(string)
+				if !ok || !strings.HasPrefix(s, secretPrefix) {
+					continue
+				}
+
+				if gcm == nil {
+					var err error
+					if gcm, err = newGCM(kp); err != nil {
+						return err
+					}
+				}
+
+				plain, err := decrypt(gcm, s)
+				if err != nil {
+					return fmt.Errorf("[%s] %s: %v", name, k, err)
+				}
+
+				if !changed {
+					values, changed = append([]interface{}(nil), values...), true
+				}
+				values[i], raws[i] = plain, plain
+			}
+
+			if !changed {
+				continue
+			}
+
+			if _, ok := sec.values[k].([]interface{}); ok {
+				sec.values[k] = values
+			} else {
+				sec.values[k] = values[0]
+			}
+			sec.raw[k] = raws
+		}
+	}
+	return nil
+}
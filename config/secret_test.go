@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSecret(t *testing.T) {
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("CONF_TEST_KEY", key)
+	kp := EnvKey("CONF_TEST_KEY")
+
+	password, _ := Encrypt(kp, "p@ss word")
+	port, _ := Encrypt(kp, "5432")
+	pin, _ := Encrypt(kp, "007")
+	if !strings.HasPrefix(password, "enc:") || strings.ContainsAny(password, "=+/") {
+		t.Fatal(password)
+	}
+
+	cf, err := ParseConf(`user = admin
+	password = ` + password + `
+	[db]
+	port = ` + port + `
+	pin = ` + pin + `
+	hosts = [a, ` + password + `]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "key")
+	os.WriteFile(path, []byte(key+"\n"), 0600)
+	if err := cf.Decrypt(FileKey(path)); err != nil {
+		t.Fatal(err)
+	}
+
+	if cf.GetString("default", "password", "") != "p@ss word" || cf.GetInt64("db", "port", 0) != 5432 ||
+		cf.GetString("default", "user", "") != "admin" || cf.GetArray("db", "hosts")[1] != "p@ss word" {
+		t.Fatal(cf.sections)
+	}
+
+	// secrets stay strings
+	if cf.GetString("db", "pin", "") != "007" || cf.GetString("db", "port", "") != "5432" {
+		t.Fatal(cf.sections["db"].values)
+	}
+
+	if cf, err := ParseEncryptedConf("password = "+password, kp); err != nil || cf.GetString("default", "password", "") != "p@ss word" {
+		t.Fatal(err)
+	}
+
+	conf := filepath.Join(t.TempDir(), "test.conf")
+	os.WriteFile(conf, []byte("password = "+password), 0644)
+	w, err := NewWatcher(conf, time.Hour, &WatchOptions{Key: kp})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	if w.Config().GetString("default", "password", "") != "p@ss word" {
+		t.Fatal(w.Config().sections)
+	}
+
+	other, _ := GenerateKey()
+	t.Setenv("CONF_TEST_KEY", other)
+	cf, _ = ParseConf("password = " + password)
+	if err := cf.Decrypt(kp); err == nil || !strings.Contains(err.Error(), "password") {
+		t.Fatal(err)
+	}
+
+	if err := cf.Decrypt(EnvKey("CONF_TEST_MISSING")); err == nil {
+		t.Fatal("missing key")
+	}
+}
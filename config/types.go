@@ -0,0 +1,152 @@
+package config
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseInt parses decimal, hex (0x), octal (0o) and binary (0b) integers,
+// unlike strconv a leading zero doesn't make the number octal
+func parseInt(s string) (int64, error) {
+	return strconv.ParseInt(s, intBase(s), 64)
+}
+
+func intBase(s string) int {
+	s = strings.TrimLeft(s, "+-")
+	if len(s) > 1 && s[0] == '0' && (s[1] >= '0' && s[1] <= '9' || s[1] == '_') {
+		return 10
+	}
+	return 0
+}
+
+// GetRaw returns the text of a value as it was written, with quotes removed, escapes and variables resolved.
+// For arrays the text of the first value is returned.
+func (c *conf_t) GetRaw(section, key string) (string, bool) {
+	if sec, ok := c.sections[section]; ok && len(sec.raw[key]) > 0 {
+		return sec.raw[key][0], true
+	}
+	return "", false
+}
+
+// raw returns the text of a single value
+func (c *conf_t) raw(section, key string) (string, bool) {
+	if sec, ok := c.sections[section]; ok && len(sec.raw[key]) == 1 {
+		return sec.raw[key][0], true
+	}
+	return "", false
+}
+
+func (c *conf_t) getInt64(section, key string) (int64, bool) {
+	if s, ok := c.raw(section, key); ok {
+		if n, err := parseInt(s); err == nil {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// GetInt64 returns the exact integer value, which can be written in decimal, hex (0x1F), octal (0o17) or binary (0b11).
+// Unlike GetInt, defaultvalue is returned if the value is a float or doesn't fit in int64.
+func (c *conf_t) GetInt64(section, key string, defaultvalue int64) int64 {
+	if n, ok := c.getInt64(section, key); ok {
+		return n
+	}
+	return defaultvalue
+}
+
+// GetUint64 is like GetInt64 but accepts values up to 2^64-1
+func (c *conf_t) GetUint64(section, key string, defaultvalue uint64) uint64 {
+	if s, ok := c.raw(section, key); ok {
+		if n, err := strconv.ParseUint(s, intBase(s), 64); err == nil {
+			return n
+		}
+	}
+	return defaultvalue
+}
+
+// GetBigInt returns the integer value of any size, nil if the value is not an integer
+func (c *conf_t) GetBigInt(section, key string) *big.Int {
+	if s, ok := c.raw(section, key); ok {
+		if n, ok := new(big.Int).SetString(s, intBase(s)); ok {
+			return n
+		}
+	}
+	return nil
+}
+
+// GetDuration returns values like "1h30m" or "250ms", plain numbers are seconds
+func (c *conf_t) GetDuration(section, key string, defaultvalue time.Duration) time.Duration {
+	s, ok := c.raw(section, key)
+	if !ok {
+		return defaultvalue
+	}
+
+	if d, err := time.ParseDuration(s); err == nil {
+		return d
+	}
+
+	if f, err := strconv.ParseFloat(s, 64); err == nil && math.Abs(f) < math.MaxInt64/float64(time.Second) {
+		return time.Duration(f * float64(time.Second))
+	}
+	return defaultvalue
+}
+
+var sizeUnits = map[string]int64{
+	"": 1, "b": 1,
+	"k": 1e3, "kb": 1e3, "m": 1e6, "mb": 1e6, "g": 1e9, "gb": 1e9, "t": 1e12, "tb": 1e12, "p": 1e15, "pb": 1e15,
+	"ki": 1 << 10, "kib": 1 << 10, "mi": 1 << 20, "mib": 1 << 20, "gi": 1 << 30, "gib": 1 << 30,
+	"ti": 1 << 40, "tib": 1 << 40, "pi": 1 << 50, "pib": 1 << 50,
+}
+
+// GetBytesSize returns sizes like "512", "64MB" or "1.5GiB". Units are case insensitive,
+// KB, MB, GB... are powers of 1000 while KiB, MiB, GiB... are powers of 1024.
+func (c *conf_t) GetBytesSize(section, key string, defaultvalue int64) int64 {
+	s, ok := c.raw(section, key)
+	if !ok {
+		return defaultvalue
+	}
+
+	i := strings.LastIndexAny(s, "0123456789.") + 1
+	unit, ok := sizeUnits[strings.ToLower(s[i:])]
+	if !ok || i == 0 {
+		return defaultvalue
+	}
+
+	if n, err := parseInt(s[:i]); err == nil {
+		if n > math.MaxInt64/unit || n < math.MinInt64/unit {
+			return defaultvalue
+		}
+		return n * unit
+	}
+
+	if f, err := strconv.ParseFloat(s[:i], 64); err == nil {
+		if f *= float64(unit); math.Abs(f) < math.MaxInt64 {
+			return int64(f)
+		}
+	}
+	return defaultvalue
+}
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"15:04:05",
+}
+
+// GetTime returns times in RFC 3339 or "2006-01-02 15:04:05", "2006-01-02", "15:04:05",
+// times without a zone are in UTC. Note that unquoted spaces are removed, so times with spaces must be quoted.
+func (c *conf_t) GetTime(section, key string, defaultvalue time.Time) time.Time {
+	if s, ok := c.raw(section, key); ok {
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t
+			}
+		}
+	}
+	return defaultvalue
+}
@@ -0,0 +1,208 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Change is a key whose value differs between two configs,
+// Old is nil if the key is new and New is nil if the key has been removed.
+type Change struct {
+	Section string
+	Key     string
+	Old     interface{}
+	New     interface{}
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("[%s] %s: %v -> %v", c.Section, c.Key, c.Old, c.New)
+}
+
+// Diff returns all changes from a to b sorted by section and key
+func Diff(a, b *conf_t) []Change {
+	var changes []Change
+	for sec, kvs := range b.sections {
+		for k, v := range kvs.values {
+			old, ok := a.get(sec, k)
+			if !ok || !reflect.DeepEqual(old, v) {
+				changes = append(changes, Change{sec, k, old, v})
+			}
+		}
+	}
+
+	for sec, kvs := range a.sections {
+		for k, v := range kvs.values {
+			if _, ok := b.get(sec, k); !ok {
+				changes = append(changes, Change{sec, k, v, nil})
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Section != changes[j].Section {
+			return changes[i].Section < changes[j].Section
+		}
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
+
+// Watcher polls a config file and reloads it when its content changes. A file which
+// can't be parsed or validated is rejected and the current config stays active.
+type Watcher struct {
+	path    string
+	opts    WatchOptions
+	current atomic.Value // *conf_t
+	content []byte
+	modTime time.Time
+	size    int64
+	subs    []func(*conf_t, []Change)
+	exit    chan struct{}
+	sync.Mutex
+}
+
+// WatchOptions controls a Watcher, nil functions are not called
+type WatchOptions struct {
+	// Validate rejects a config by returning an error
+	Validate func(*conf_t) error
+	// OnError is called from the polling goroutine when the file can't be reloaded
+	OnError func(error)
+	// Key decrypts secret values before validation if it is not nil
+	Key KeyProvider
+}
+
+// NewWatcher loads the config file at path and checks it for changes every interval,
+// opts can be nil. It fails if interval is not positive or the initial file is invalid.
+func NewWatcher(path string, interval time.Duration, opts *WatchOptions) (*Watcher, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid watch interval: %v", interval)
+	}
+
+	w := &Watcher{
+		path: path,
+		exit: make(chan struct{}),
+	}
+	if opts != nil {
+		w.opts = *opts
+	}
+
+	if _, err := w.Reload(); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-t.C:
+				if _, err := w.Reload(); err != nil && w.opts.OnError != nil {
+					w.opts.OnError(err)
+				}
+			case <-w.exit:
+				return
+			}
+		}
+	}()
+
+	return w, nil
+}
+
+// Config returns the active config
+func (w *Watcher) Config() *conf_t {
+	return w.current.Load().(*conf_t)
+}
+
+// Subscribe registers a callback which will be called with the new config and the changes
+// every time a different config becomes active
+func (w *Watcher) Subscribe(callback func(cf *conf_t, changes []Change)) {
+	w.Lock()
+	w.subs = append(w.subs, callback)
+	w.Unlock()
+}
+
+// Reload checks the file immediately and returns whether a new config has been activated.
+// Subscribers are called after the watcher is unlocked, so they can call Reload and Subscribe.
+func (w *Watcher) Reload() (bool, error) {
+	cf, changes, subs, err := w.reload()
+	if err != nil || cf == nil {
+		return false, err
+	}
+
+	if len(changes) > 0 {
+		for _, cb := range subs {
+			cb(cf, changes)
+		}
+	}
+	return true, nil
+}
+
+// reload returns the new config activated, the changes and the subscribers to notify
+func (w *Watcher) reload() (*conf_t, []Change, []func(*conf_t, []Change), error) {
+	w.Lock()
+	defer w.Unlock()
+
+	fi, err := os.Stat(w.path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if w.content != nil && fi.ModTime().Equal(w.modTime) && fi.Size() == w.size {
+		return nil, nil, nil, nil
+	}
+
+	buf, err := os.ReadFile(w.path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	w.modTime, w.size = fi.ModTime(), fi.Size()
+	if w.content != nil && bytes.Equal(buf, w.content) {
+		return nil, nil, nil, nil
+	}
+	w.content = buf
+
+	cf, err := ParseConf(string(buf))
+	if err == nil && w.opts.Key != nil {
+		err = cf.Decrypt(w.opts.Key)
+	}
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%s: %w", w.path, err)
+	}
+
+	if w.opts.Validate != nil {
+		if err := w.opts.Validate(cf); err != nil {
+			return nil, nil, nil, fmt.Errorf("%s: %w", w.path, err)
+		}
+	}
+
+	old, _ := w.current.Load().(*conf_t)
+	w.current.Store(cf)
+
+	var changes []Change
+	if old != nil {
+		changes = Diff(old, cf)
+	}
+	subs := make([]func(*conf_t, []Change), len(w.subs))
+	copy(subs, w.subs)
+	return cf, changes, subs, nil
+}
+
+// Close stops watching the file, it can be called more than once
+func (w *Watcher) Close() {
+	w.Lock()
+	defer w.Unlock()
+
+	select {
+	case <-w.exit:
+	default:
+		close(w.exit)
+	}
+}
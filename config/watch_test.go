@@ -0,0 +1,120 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.conf")
+	os.WriteFile(path, []byte("a=1\n[s]\nb=on"), 0644)
+
+	w, err := NewWatcher(path, time.Hour, &WatchOptions{
+		Validate: func(cf *conf_t) error {
+			if cf.GetInt("default", "a", 0) <= 0 {
+				return fmt.Errorf("a must be positive")
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	var changes []Change
+	w.Subscribe(func(cf *conf_t, c []Change) { changes = c })
+
+	os.WriteFile(path, []byte("a=2\n[s]\nc=off"), 0644)
+	if ok, err := w.Reload(); !ok || err != nil {
+		t.Fatal(ok, err)
+	}
+
+	if w.Config().GetInt("default", "a", 0) != 2 || len(changes) != 3 ||
+		changes[0].Key != "a" || changes[1].Key != "b" || changes[1].New != nil || changes[2].Key != "c" {
+		t.Fatal(changes)
+	}
+
+	os.WriteFile(path, []byte("a=-1"), 0644)
+	if ok, err := w.Reload(); ok || err == nil {
+		t.Fatal(ok, err)
+	}
+
+	if w.Config().GetInt("default", "a", 0) != 2 {
+		t.Fatal("invalid config activated")
+	}
+}
+
+func TestWatcherOnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.conf")
+	os.WriteFile(path, []byte("a=1"), 0644)
+
+	errs := make(chan error, 1)
+	w, err := NewWatcher(path, time.Millisecond, &WatchOptions{
+		OnError: func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	os.Remove(path)
+	select {
+	case err := <-errs:
+		if !os.IsNotExist(err) {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("OnError is not called")
+	}
+}
+
+func TestWatcherReentrant(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.conf")
+	os.WriteFile(path, []byte("a=1"), 0644)
+
+	if _, err := NewWatcher(path, 0, nil); err == nil {
+		t.Fatal("zero interval accepted")
+	}
+
+	w, err := NewWatcher(path, time.Hour, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	called := 0
+	w.Subscribe(func(cf *conf_t, c []Change) {
+		called++
+		w.Subscribe(func(*conf_t, []Change) {})
+		if ok, err := w.Reload(); ok || err != nil {
+			t.Error(ok, err)
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		os.WriteFile(path, []byte("a=22"), 0644)
+		w.Reload()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("subscriber deadlocked")
+	}
+	if called != 1 {
+		t.Fatal(called)
+	}
+
+	w.Close()
+	w.Close()
+}
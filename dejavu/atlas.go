@@ -0,0 +1,239 @@
+package dejavu
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"io"
+)
+
+// Atlas is a set of glyphs stacked vertically in one alpha image, every glyph takes a cell
+// of Width x (Ascent+Descent) pixels. Runes lists the rune of each cell and Advances the advance
+// of each glyph, nil Advances means all glyphs advance by Width.
+type Atlas struct {
+	Image                  *image.Alpha
+	Width, Ascent, Descent int
+	Runes                  []rune
+	Advances               []int
+}
+
+// Font creates a font from the atlas, glyphs share the pixels of the atlas.
+// It panics if the image is too small for the cells.
+func (a *Atlas) Font(replacement rune) *Font {
+	f := NewFont(a.Ascent, a.Descent, replacement)
+	h := a.Ascent + a.Descent
+
+	if a.Width > a.Image.Rect.Dx() || len(a.Runes)*h > a.Image.Rect.Dy() || (a.Advances != nil && len(a.Advances) != len(a.Runes)) {
+		panic(fmt.Sprintf("dejavu: atlas of %d %dx%d glyphs doesn't match image %v", len(a.Runes), a.Width, h, a.Image.Rect))
+	}
+
+	for i, r := range a.Runes {
+		start := a.Image.PixOffset(a.Image.Rect.Min.X, a.Image.Rect.Min.Y+i*h)
+		g := &Glyph{
+			Mask: &image.Alpha{
+				Pix:    a.Image.Pix[start : start+(h-1)*a.Image.Stride+a.Width],
+				Stride: a.Image.Stride,
+				Rect:   image.Rect(0, -a.Ascent, a.Width, a.Descent),
+			},
+			Advance: a.Width,
+		}
+
+		if a.Advances != nil {
+			g.Advance = a.Advances[i]
+		}
+		f.Add(r, g)
+	}
+	return f
+}
+
+// NewAtlas rasterizes the glyphs of runes into an atlas, runes not covered by the font are skipped.
+// Glyphs are drawn with their dots at the left of the baselines of cells, the width of cells fits
+// the widest glyph and pixels outside cells are clipped.
+func NewAtlas(f *Font, runes []rune) *Atlas {
+	a := &Atlas{Ascent: f.Ascent, Descent: f.Descent}
+	var glyphs []*Glyph
+	for _, r := range runes {
+		g, ok := f.Glyph(r)
+		if !ok {
+			continue
+		}
+
+		a.Runes, glyphs = append(a.Runes, r), append(glyphs, g)
+		a.Advances = append(a.Advances, g.Advance)
+		if w := maxInt(g.Advance, g.Mask.Rect.Max.X); w > a.Width {
+			a.Width = w
+		}
+	}
+
+	fixed := true
+	for _, adv := range a.Advances {
+		fixed = fixed && adv == a.Width
+	}
+	if fixed {
+		a.Advances = nil
+	}
+
+	h := a.Ascent + a.Descent
+	a.Image = image.NewAlpha(image.Rect(0, 0, a.Width, len(glyphs)*h))
+	for i, g := range glyphs {
+		cell := image.Rect(0, i*h, a.Width, (i+1)*h)
+		g.draw(a.Image, 0, i*h+a.Ascent, image.Opaque, cell)
+	}
+	return a
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// MarshalBinary encodes the atlas compactly, runes must be in ascending order
+func (a *Atlas) MarshalBinary() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w, _ := flate.NewWriter(buf, flate.BestCompression)
+
+	var tmp [binary.MaxVarintLen64]byte
+	put := func(v int) {
+		w.Write(tmp[:binary.PutUvarint(tmp[:], uint64(v))])
+	}
+
+	put(a.Width)
+	put(a.Ascent)
+	put(a.Descent)
+	put(len(a.Runes))
+	for i, r := range a.Runes {
+		prev := rune(-1)
+		if i > 0 {
+			prev = a.Runes[i-1]
+		}
+		if r <= prev {
+			return nil, fmt.Errorf("dejavu: runes of atlas are not in ascending order: %q", r)
+		}
+		put(int(r - prev))
+	}
+
+	if a.Advances == nil {
+		put(0)
+	} else {
+		put(1)
+		for _, adv := range a.Advances {
+			put(adv)
+		}
+	}
+
+	h := a.Ascent + a.Descent
+	for y := 0; y < len(a.Runes)*h; y++ {
+		i := a.Image.PixOffset(a.Image.Rect.Min.X, a.Image.Rect.Min.Y+y)
+		w.Write(a.Image.Pix[i : i+a.Width])
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the atlas encoded by MarshalBinary
+func (a *Atlas) UnmarshalBinary(data []byte) error {
+	r := flate.NewReader(bytes.NewReader(data))
+	br := &byteReader{r: r}
+
+	get := func() int {
+		v, err := binary.ReadUvarint(br)
+		if err != nil && br.err == nil {
+			br.err = err
+		}
+		return int(v)
+	}
+
+	res := Atlas{Width: get(), Ascent: get(), Descent: get()}
+	n, h := get(), res.Ascent+res.Descent
+	if br.err == nil && (n > 1<<21 || res.Width*h*n > 1<<28) {
+		return fmt.Errorf("dejavu: atlas is too large")
+	}
+
+	prev := rune(-1)
+	for i := 0; i < n && br.err == nil; i++ {
+		prev += rune(get())
+		res.Runes = append(res.Runes, prev)
+	}
+
+	if get() == 1 {
+		for i := 0; i < n && br.err == nil; i++ {
+			res.Advances = append(res.Advances, get())
+		}
+	}
+
+	if br.err != nil {
+		return fmt.Errorf("dejavu: invalid atlas: %v", br.err)
+	}
+
+	res.Image = image.NewAlpha(image.Rect(0, 0, res.Width, n*h))
+	if _, err := io.ReadFull(r, res.Image.Pix); err != nil {
+		return fmt.Errorf("dejavu: invalid atlas: %v", err)
+	}
+
+	*a = res
+	return nil
+}
+
+type byteReader struct {
+	r   io.Reader
+	err error
+}
+
+func (br *byteReader) ReadByte() (byte, error) {
+	var b [1]byte
+	if br.err != nil {
+		return 0, br.err
+	}
+
+	if _, err := io.ReadFull(br.r, b[:]); err != nil {
+		br.err = err
+		return 0, err
+	}
+	return b[0], nil
+}
+
+// generatedAtlases holds the base64 of the atlases generated into this package by name, they are
+// registered by init functions and decoded on first use, so atlasgen, which imports this package,
+// doesn't depend on them
+var generatedAtlases = map[string]string{}
+
+// generatedFont returns a font whose glyphs are decoded from a generated atlas on first use
+func generatedFont(name string, ascent, descent int, replacement rune) *Font {
+	f := NewFont(ascent, descent, replacement)
+	f.derive = func(g *Glyph) *Glyph { return g }
+	f.load = func() *Font {
+		b64, ok := generatedAtlases[name]
+		if !ok {
+			panic("dejavu: atlas " + name + " is not generated")
+		}
+
+		a := MustDecodeAtlas(b64)
+		if a.Ascent != ascent || a.Descent != descent {
+			panic(fmt.Sprintf("dejavu: atlas %s has ascent %d and descent %d", name, a.Ascent, a.Descent))
+		}
+		return a.Font(0)
+	}
+	return f
+}
+
+// MustDecodeAtlas decodes the base64 of MarshalBinary and panics on errors, it is used by generated atlases
+func MustDecodeAtlas(b64 string) *Atlas {
+	data, err := base64.StdEncoding.DecodeString(b64)
+	if err != nil {
+		panic(err)
+	}
+
+	a := &Atlas{}
+	if err := a.UnmarshalBinary(data); err != nil {
+		panic(err)
+	}
+	return a
+}
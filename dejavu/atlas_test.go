@@ -0,0 +1,76 @@
+package dejavu
+
+import (
+	"bytes"
+	"image"
+	"strings"
+	"testing"
+)
+
+func TestAtlas(t *testing.T) {
+	a := MustDecodeAtlas(generatedAtlases["defaultAtlas"])
+	if a.Width != Width || a.Ascent != Height || a.Descent != FullHeight-Height || string(a.Runes) != string(runeRange(0x20, 0x7e)) {
+		t.Fatal(a)
+	}
+
+	data, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := &Atlas{}
+	if err := b.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if b.Width != a.Width || b.Ascent != a.Ascent || b.Descent != a.Descent || b.Advances != nil ||
+		string(b.Runes) != string(a.Runes) || !bytes.Equal(b.Image.Pix, a.Image.Pix) {
+		t.Fatal(b)
+	}
+
+	// the atlas of a font is the same as the one it is created from
+	c := NewAtlas(b.Font('?'), a.Runes)
+	if c.Width != a.Width || c.Advances != nil || !bytes.Equal(c.Image.Pix, a.Image.Pix) {
+		t.Fatal(c)
+	}
+
+	f, _ := LoadBDF(strings.NewReader(testBDF))
+	c = NewAtlas(f, []rune{'a', 'é', '中'})
+	if c.Width != 8 || string(c.Runes) != "é中" || len(c.Advances) != 2 || c.Advances[0] != 6 || c.Image.Rect != image.Rect(0, 0, 8, 16) {
+		t.Fatal(c)
+	}
+
+	data, _ = c.MarshalBinary()
+	if err := b.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	g, ok := b.Font(0).Glyph('中')
+	if fg, _ := f.Glyph('中'); !ok || g.Advance != 8 || g.Mask.AlphaAt(0, -5).A != 0xff || g.Mask.AlphaAt(0, -5) != fg.Mask.AlphaAt(0, -5) {
+		t.Fatal(g)
+	}
+
+	for _, data := range [][]byte{nil, data[:len(data)/2], []byte("not an atlas")} {
+		if err := b.UnmarshalBinary(data); err == nil {
+			t.Fatal(data)
+		}
+	}
+
+	c.Runes = []rune{'中', 'é'}
+	if _, err := c.MarshalBinary(); err == nil {
+		t.Fatal("runes are not sorted")
+	}
+
+	for r := rune(0x20); r <= 0x7e; r++ {
+		if g, ok := DefaultBold.Glyph(r); !ok || g.Advance != Width || g.Mask.Rect != image.Rect(0, -Height, Width, FullHeight-Height) {
+			t.Fatal(r, g)
+		}
+	}
+
+	// generated atlases are decoded on first use
+	f = generatedFont("missing", Height, FullHeight-Height, '?')
+	defer func() {
+		if recover() == nil {
+			t.Fatal("missing atlas is used")
+		}
+	}()
+	f.Glyph('a')
+}
@@ -0,0 +1,177 @@
+package dejavu
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// LoadBDF loads a font in the Glyph Bitmap Distribution Format, e.g. GNU Unifont for CJK.
+// Encodings of glyphs are taken as Unicode code points, glyphs without one are skipped.
+// The replacement rune is DEFAULT_CHAR if the font defines it, U+FFFD otherwise.
+func LoadBDF(r io.Reader) (*Font, error) {
+	br := &bdfReader{s: bufio.NewScanner(r)}
+	br.s.Buffer(nil, 1<<20)
+
+	var bbox []int
+	ascent, descent, def := -1, -1, rune(0xfffd)
+	var f *Font
+
+	for {
+		kw, args := br.next()
+		var v []int
+		var err error
+
+		switch kw {
+		case "":
+			if err := br.s.Err(); err != nil {
+				return nil, err
+			}
+			return nil, br.errorf("unexpected end of file")
+		case "FONTBOUNDINGBOX":
+			bbox, err = br.ints(args, 4)
+		case "FONT_ASCENT":
+			if v, err = br.ints(args, 1); err == nil {
+				ascent = v[0]
+			}
+		case "FONT_DESCENT":
+			if v, err = br.ints(args, 1); err == nil {
+				descent = v[0]
+			}
+		case "DEFAULT_CHAR":
+			if v, err = br.ints(args, 1); err == nil {
+				def = rune(v[0])
+			}
+		case "CHARS":
+			if bbox == nil {
+				return nil, br.errorf("FONTBOUNDINGBOX is missing")
+			}
+
+			if ascent < 0 {
+				ascent = bbox[1] + bbox[3]
+			}
+			if descent < 0 {
+				descent = -bbox[3]
+			}
+			f = NewFont(ascent, descent, def)
+		case "STARTCHAR":
+			if f == nil {
+				return nil, br.errorf("STARTCHAR before CHARS")
+			}
+
+			r, g, err := br.glyph(bbox)
+			if err != nil {
+				return nil, err
+			}
+
+			if r >= 0 {
+				f.Add(r, g)
+			}
+		case "ENDFONT":
+			if f == nil {
+				return nil, br.errorf("no glyphs")
+			}
+			return f, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+type bdfReader struct {
+	s  *bufio.Scanner
+	ln int
+}
+
+func (br *bdfReader) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("bdf: line %d: %s", br.ln, fmt.Sprintf(format, args...))
+}
+
+// next returns the keyword and arguments of the next non-empty line, empty keyword at the end
+func (br *bdfReader) next() (string, []string) {
+	for br.s.Scan() {
+		br.ln++
+		if fields := strings.Fields(br.s.Text()); len(fields) > 0 {
+			return fields[0], fields[1:]
+		}
+	}
+	return "", nil
+}
+
+func (br *bdfReader) ints(args []string, n int) ([]int, error) {
+	if len(args) < n {
+		return nil, br.errorf("expect %d numbers", n)
+	}
+
+	res := make([]int, n)
+	for i := range res {
+		v, err := strconv.Atoi(args[i])
+		if err != nil {
+			return nil, br.errorf("%v", err)
+		}
+		res[i] = v
+	}
+	return res, nil
+}
+
+// glyph reads a glyph after STARTCHAR, the rune is -1 if the glyph has no encoding
+func (br *bdfReader) glyph(bbox []int) (rune, *Glyph, error) {
+	r, advance, bbx := rune(-1), bbox[0], bbox
+
+	for {
+		kw, args := br.next()
+		var v []int
+		var err error
+
+		switch kw {
+		case "", "ENDFONT":
+			return 0, nil, br.errorf("unexpected end of glyph")
+		case "ENCODING":
+			if v, err = br.ints(args, 1); err == nil {
+				r = rune(v[0])
+			}
+		case "DWIDTH":
+			if v, err = br.ints(args, 1); err == nil {
+				advance = v[0]
+			}
+		case "BBX":
+			bbx, err = br.ints(args, 4)
+		case "BITMAP":
+			w, h, xoff, yoff := bbx[0], bbx[1], bbx[2], bbx[3]
+			if w < 0 || h < 0 {
+				return 0, nil, br.errorf("invalid glyph size %dx%d", w, h)
+			}
+
+			m := image.NewAlpha(image.Rect(xoff, -(yoff + h), xoff+w, -yoff))
+
+			for y := 0; y < h; y++ {
+				kw, _ := br.next()
+				row, err := hex.DecodeString(kw)
+				if err != nil || len(row)*8 < w {
+					return 0, nil, br.errorf("invalid bitmap row %q", kw)
+				}
+
+				for x := 0; x < w; x++ {
+					if row[x/8]&(0x80>>(x%8)) != 0 {
+						m.Pix[y*m.Stride+x] = 0xff
+					}
+				}
+			}
+
+			if kw, _ := br.next(); kw != "ENDCHAR" {
+				return 0, nil, br.errorf("expect ENDCHAR, got %q", kw)
+			}
+			return r, &Glyph{Mask: m, Advance: advance}, nil
+		}
+
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+}
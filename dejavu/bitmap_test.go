@@ -0,0 +1,54 @@
+package dejavu
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestDrawText(t *testing.T) {
+	src := image.NewUniform(color.Alpha{0xff})
+	canvas := image.NewAlpha(image.Rect(-5, -5, 30, 20))
+
+	// runes out of the atlas used to index outside of it
+	for _, text := range []string{"\x00\x1f\x7f", "é中😀", "\xff\xfe", string(rune(0x10ffff))} {
+		DrawText(canvas, text, 0, Height, src)
+		for _, p := range canvas.Pix {
+			if p != 0 {
+				t.Fatalf("%q is drawn", text)
+			}
+		}
+	}
+
+	// partially or totally outside the canvas
+	for _, p := range []image.Point{{-10, 0}, {25, 25}, {-100, -100}, {1 << 30, 1 << 30}} {
+		DrawText(canvas, "Hello", p.X, p.Y, src)
+	}
+
+	ref, out := image.NewAlpha(image.Rect(0, 0, 3*Width, FullHeight)), image.NewAlpha(image.Rect(0, 0, 3*Width, FullHeight))
+	DrawText(ref, "a c", 0, Height, src)
+	DrawText(out, "a\tc", 0, Height, src)
+	if !reflect.DeepEqual(ref.Pix, out.Pix) {
+		t.Fatal("unsupported rune should leave its cell empty")
+	}
+
+	err := DrawTextStrict(out, "a\tbéb\t", 0, Height, src)
+	var ue *UnsupportedError
+	if !errors.As(err, &ue) || !reflect.DeepEqual(ue.Runes, []rune{'\t', 'é'}) || err.Error() != "dejavu: unsupported characters: U+0009, U+00E9 'é'" {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(ref.Pix, out.Pix) {
+		t.Fatal("strict mode should draw nothing on errors")
+	}
+
+	if err := DrawTextStrict(out, "~ok~", 0, Height, src); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := (&Layout{}).Check("a\tb\r\nc"); err != nil {
+		t.Fatal(err)
+	}
+}
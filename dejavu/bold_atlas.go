@@ -0,0 +1,58 @@
+// Code generated by atlasgen -ttf fonts/DejaVuSansMono-Bold.ttf -size 10.8 -ascent 10 -descent 2 -var boldAtlas -o bold_atlas.go; DO NOT EDIT.
+
+package dejavu
+
+// boldAtlas holds 95 glyphs of 7x12 pixels
+func init() {
+	generatedAtlases["boldAtlas"] = "" +
+		"xJl5fIzn2se/M5nsi9CQ5CS15iSOINEMoeKlOBGVKm0QWzIhiGNLLS2tVlq7aglVS0OVIFWtpbGcllK1RCkSIhoqUwmZECQk" +
+		"ss3M9X5mtKVlRvT0vO/vn+/n81z385vrvu/nvp77fsbeSRnbRPFfFf8XGpENwOSyB/FKKQBD8wBopgGg/asPNrGirW/V1XaE" +
+		"jMUe0v03uDgq3FX/QZ4jX++wCfg2YfYmemdUZ9/I1zRbpBsp41p6vr896np99CIiWupWBWaMtqdbgVO1n0UzVVMAPNIA4i5V" +
+		"n22Na83KvXGB1NHvSLcBRt+UgmCgwXcXNuOxrN/+nA247CzRH/UDPM334TL8IWPn+HCARRtPmfjZvzYNw4GAtPfazVng4Bi7" +
+		"NCGyPh3ULN931QfI+sfnvYGoS9vsrHa9R57hMJBxqv0AYOMVZ0CZJu8BrxW+awg9EL1vl82x7Fsd1lVGxUgWT58Uw6maEPBy" +
+		"s2w67CQAicUPwt7tT85OveYAbPsQoK2xBcCaTACObwagZAEA5z4BYMsRAPpVeQIoTr4BQFAUv8nd7MHfqoIA2LwDgL7iA9BC" +
+		"egI4yQSAJtIfIFwCAZLPKAHnkhcBGo7gdzKFsAvdij0BWQuObFgKLa7LEgjImnd4/Qe/xv68fDoDoNECStUwrUpFmunBFL+A" +
+		"sNmFYWEOv8SgzaS/cBXPLakHsKLSGeBMMoDNTM8nMxms1R4HuqelrfhvVZuVJQ0BUqXx49uOXg1ge7kbQMwJAMXZfgCRF5UA" +
+		"hxIAOuocAHa8DtCi1N2yYYec0o9UKLTHpkg8wTKYC1/yvMSO2P8DvWXsrH2ZhEoUZ7/C9vquKMMk6HdX9rsCtg0ses45lRcK" +
+		"7NklnQG6WYQidoHMjcHG9CzUWO13sxMVJavtaLWg+2xJBHCXOQBv61sBQwzxwAvVY4C2Ffs1mmeJN3mmWPV86nTlnS2ueLw/" +
+		"8G15E8C7aiJwTr/KDZj8eWlPADK3EhofOqD8U3qUi5z5O6iebmDFdnNeU2CIUQLA49oKaQ6pq6OlOd2veQ+V1kw1P/qqZpGR" +
+		"8yVBAZhiVnVRq9V2BX1qUpI/6GMaAabSfswDogNGySIALuyhWf+QRFlA27siu+uAbSPLpcvzixvFUfDNT/1iw2kk/Zu6QGe5" +
+		"KGU9CJcdrXMzCJYE1l7GtmhXu4v/hpfuSEEbwMZXacGys1ar1a7CLylpoSwBiJdggGPHAVrJSIAlZa6Aw80UgMESymM0ML8i" +
+		"vQ72lVsHV8zAqTKGS+/AyArtD97YHMhKKelNqASzbTdBMr/j5a2QbBRtEOBqMU+G5eS+raS5IWWKRBMrTbixiZdlQDPjtzhf" +
+		"kpqyQ+Ae9T9H0uAFz8HG/nBNjB8owTnQpbZrenjKUw+s6ZA+Tn9yvT+h5+Yac5Oc1QCKtxta9XNWACErbtu7JfxQuaWPYq5h" +
+		"Xh2g9fq7x8Z7AnXHZentAWhn8wTFXm2SYp75KXdQOZjE2BMm2XVMNOkJzFwBYO7xES7A02/9eGdVW4BO58VB+VzKrexXFDPL" +
+		"P+4IBNbiLT3xcvUhH1g5fEjNfICAmtGA1+VPFcDiS3YAKZsA6DcYgG17H2vasHdrYFbxV7ffoHeul3ObStXXsUOqCqv/9nPz" +
+		"S/299b5FTYrC7Mp9DvdanHfE4PuvQ/bPh3W1V+36fnzCMlAMWvbx0Ic2tbu1PQAPY9kSYIB+WQ6QcrK3+MLP21+QOPxNg7uR" +
+		"MdLUec01xbYLMESCf737UXpBqz2X1phomfJK2XZzxTm7gwGScyU7mGiZObNoo/niIqkTLTFR2RWqaJGK7H7Wu+64XHd3AXwo" +
+		"y6etwLZyGyhoItMA/OVVcwvDegDSqie9PAM89xrlEwA3H4s15Mc9QyFCPjsoCURIhCI30wTSbpux4a4Zp3OIkA9SZRoRUnX+" +
+		"TetbRS9TXdqKr+xJSorHVxIBfOXyiROx+Mq3ixd3u39xo0bTC1/T6GZY9XQyeR7ARQ4mJY3HRWYBuMjVEycm4iLHFi/ue//i" +
+		"g7Ce6LaSa+PgSMXMpFW0lhngQF/p6duxA/3ln6/JHdrJRMdJd1BkXxv04R1oeU4MHwM0cLY4np3ZutU8ZhkZ91GkrTIhNakg" +
+		"46GYGdZU39T32bgkHZJZkcA8ceDRcNTslBEd8DKN52O2T420Wm041EtaI30Awh6CHYRLL0Yn990jLXj+hshKwOZpV0uWA7T+" +
+		"rD/VYLgEvmboSbxM0L8Ow6W6pgHEy5nqZBPafVjVhHhp6V2eagLzjFbXEbtSATi99/HI1mg0dpw2dd7dmuXrWo3iVJ7Tu4Yv" +
+		"ggzi/O7Zoqknxfnd4wd1ySbM0keb4Nq4lwlwH9Y8o7SjmHQAjWQx6yIauRpixrxlZvjfXGgC310wI07McL59AauKyL29wQm3" +
+		"su9nGt4hWrpy8DxTxI/UMqZJE9ZVEisd+ToPz+ovYyuXwMhKOdYAsGtgedrzsjYG4CebF5Sewk8S2Gyw8ZP3h1z5GT+RmjPP" +
+		"4ScTlGCO3cd/lGfZvTwz7+W5CkZVSrE3YPdCVTwAz/7xuNNVm5eZ4kGkfPChrCRSunB1N5GSvMQwnF5yUzaZZqTzNOlkgv2V" +
+		"b0zgFelsNc/xhdWZYQQY016aP4RnZTyATa583w1wnZxnHA7gePFbui3t1L30K1r/LPJTCCi8vC19B3nNtDa19s9NPyhJ022s" +
+		"rc0/wrKGab1YmEWi+LK25NGIlwA+y6eDbI4r2QPLRfJDAGcfS3nOOaNgRF6DOPFnoxY/GcLFVChcUk9GwWcZERIIEypn3lDA" +
+		"M5K/A7C5LVOs53l2IHA8PvtNoGrAzmXDTtSRjsu/WCeR0nDq0cy9y/SqgVeujMwr4FnZHSRH8ZGZNmWbrXpOO1+ft849Ve/G" +
+		"ysaVSTBGfyTfCWzOiOm3bLLMGG04ftmRusUpzapmkHzbk/nlvtY8U4/botGqu0qc6qeDcCBXI12gs5R9A/CNdAKYK/YPw7LM" +
+		"e2+tk19S0ly9zhaYL72ADvoVgOOPuU7Aopp2QHvjIY1G42I+H1ncgTZXBwFkFO8xYy1PiJw5AFFj4/5o3Oa0AwBHEwDoc+/Q" +
+		"qjjXD4C4EwDY5ncHYOLXALjceAaAmZ8+9CZSq92Al7IkAqDxEyLsEzMCx459xEZ5/aV2wLTyg/n1+eeNMNtN39g4OYLCXcn/" +
+		"jyab9SwAwOi3AHA7Fg+A+4t//S8qVAC7qw3ZbWBym5YXtmIz53R+RSZDZW14Vg6J0usfFTl4XzTmZ+c8+mjopK4LoJaoX6A0" +
+		"4+Ob+aGoJSe++DBqmcy6CtQylo+qFWrJ6J5/CrV8UVPRE8DN8bGJ2iqApt/W3B0PB29FRWpwMK4GcP5l13WyIEzdF4JyxTgd" +
+		"wLvOo2wC/QA4mw4oTVCsrdqRm04PSZ0p6UyVpsrSdN4QH4q/5EV5N16+RLVXjl9KB2rzXckGIPaK/rAfgfpVXc8fYLxs+ehs" +
+		"jWqSrF64cKFDe1natM1YmFopsgVQNXpUnvXb+AHRBsN2YO0te6hXWGEoXI9tjwPXezwDbMoHS6htmiyuzji4k1D5NFGOMk4C" +
+		"yTvKWAki/yjBsmeOHIVJF7adOQRdXIMqNkCuSFEIAIBK3QDASxJ/gcKMT6/pWuElmTGyCC95A916U4yC1D/Agp7LCASIkvYA" +
+		"SgcFcECnM/W54yxp/0DMDJfgyTI02K7LvY+NFjxdMjQA7jIdAAcbIFmnSwRavSzTH4j9HnXlDYCeMgo6lcn1+0caldoLwEOm" +
+		"/h5/z01VPSXJeV854iFGaW66+O9zu82xcBlsAtuuPfXILH3U6obAbL18AECZFZSuAuBcbic/oF+hfFfL9Y4S3vmubEPZDhad" +
+		"WViRYLRbnD6koKV43IPX71FbU6ivW3Nv3k8N+nPz/gcpAWKuVqV74F+zPe72WkZJMNsLGSMt2HyNYOOaiBufw2vVcroR4OBd" +
+		"uzyDdEuLC9qjlmzN9SOoZdK9ujSOlCqFWk5EXjmJWnboy8NNhczd4beyZi1NxdqqbYfXEy6fzJB00/7TVEOmiy830ukjS8ZK" +
+		"Oqqv5Ic8cw1RnE2/X4KsuI7VvV1y04WpcuDlRGemij/AVPF4GLWSLcCUUrn6DxwMyxv1aQxnyjY9B9SZ8r0MgEFu7qWLoVCM" +
+		"RW0Bz0aW/7WaEwOALhWI0xkqdCtoGHFzX0TQrxd/w/nM0EbACJ1xE7WXn24QP82nucRTtuJXBMhIZeVyvGRjrKyAnbKvfDko" +
+		"vGvpOaLQm/fOKZtKDJfWQWaqn/SFpKIx5U4QJBe2AvwkMU+QJtl9gSOa/TP8X7at6Zy88X1je6k7/OThfcvyaVv+47DinTgZ" +
+		"NvrL3CfwDLky1f3CBph/Z90tX7A7JQOBOlqZBKQWzalsxUAZbncx6wn+rXn1qiezf3bzM45TFHwO+4+GSW8YLOlFKtN3WlkE" +
+		"sEyCALtDx4HeVRXtH/cC0el0uvUounSZLdOB1qUbFOCTv98OHDN17QN8aSYi8qVFB9dWvkDL8qIJwChxBkisAWDhbcDtdNUI" +
+		"wHZo7mEAkqoAmGAAYJAkNAWclu4bw2/ybAfAkPN/BQBQhjQBcMwyrAFgWT4A04sBmFjjCuBz5NZ4oGfNokBg2l1bgKTrgE14" +
+		"Rrbp9u+2h9dyrvxDlLgqx1ffvbqlwD7Eya73O92stv/fAQA=" +
+		""
+}
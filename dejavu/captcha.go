@@ -0,0 +1,203 @@
+package dejavu
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"strings"
+
+	"github.com/coyove/common/rand"
+	"github.com/coyove/common/session"
+)
+
+// CaptchaCharset leaves out characters which are easily confused, like 0 and O
+const CaptchaCharset = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// Captcha generates challenge images of random text, the zero value is ready to use
+type Captcha struct {
+	Width, Height int    // size of images, 0 means 160x60
+	Length        int    // number of characters, 0 means 5
+	Charset       string // characters to choose from, empty means CaptchaCharset
+	NoiseLines    int    // number of lines across the text, 0 means 4 and negative means none
+	Font          *Font  // nil means Default
+}
+
+// Challenge is a generated captcha, Token is sent to the client with the image
+// and verified with the answer of the user by VerifyCaptcha
+type Challenge struct {
+	Answer string
+	PNG    []byte
+	Token  string
+}
+
+var captchaRand = rand.New()
+
+func randFloat(min, max float64) float64 {
+	return min + (max-min)*float64(captchaRand.Int63n(1<<53))/(1<<53)
+}
+
+func (c *Captcha) size() (int, int) {
+	if c.Width <= 0 || c.Height <= 0 {
+		return 160, 60
+	}
+	return c.Width, c.Height
+}
+
+// New generates a challenge of random text
+func (c *Captcha) New() (*Challenge, error) {
+	n, charset := c.Length, []rune(c.Charset)
+	if n <= 0 {
+		n = 5
+	}
+	if len(charset) == 0 {
+		charset = []rune(CaptchaCharset)
+	}
+
+	answer := make([]rune, n)
+	for i := range answer {
+		answer[i] = charset[captchaRand.Intn(len(charset))]
+	}
+
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, c.Draw(string(answer))); err != nil {
+		return nil, err
+	}
+
+	return &Challenge{
+		Answer: string(answer),
+		PNG:    buf.Bytes(),
+		Token:  session.NewString(answerHash(string(answer))),
+	}, nil
+}
+
+// answerHash returns 4 bytes identifying the answer, which are sealed in the session token
+func answerHash(answer string) string {
+	h := sha256.Sum256([]byte(strings.ToUpper(strings.TrimSpace(answer))))
+	return string(h[:4])
+}
+
+// VerifyCaptcha checks the answer of a challenge case-insensitively, each token can be verified only once:
+// it is consumed before the answer is compared, whether the answer is right or not
+func VerifyCaptcha(token, answer string) bool {
+	hash, ok := session.TakeString(token)
+	return ok && subtle.ConstantTimeCompare([]byte(hash), []byte(answerHash(answer))) == 1
+}
+
+// Draw renders text with per-glyph jitter and rotation, noise lines and a wave distortion
+func (c *Captcha) Draw(text string) *image.RGBA {
+	w, h := c.size()
+	f := c.Font
+	if f == nil {
+		f = Default
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, w, h))
+	bg := color.RGBA{uint8(220 + captchaRand.Intn(36)), uint8(220 + captchaRand.Intn(36)), uint8(220 + captchaRand.Intn(36)), 0xff}
+	for i := 0; i < len(canvas.Pix); i += 4 {
+		copy(canvas.Pix[i:], []byte{bg.R, bg.G, bg.B, bg.A})
+	}
+
+	runes := []rune(text)
+	if len(runes) > 0 {
+		// capitals take most of the height and the text takes most of the width
+		m, _ := f.Glyph('M')
+		scale := float64(h) * 0.6 / float64(f.Ascent)
+		if s := float64(w) * 0.8 / float64(len(runes)*m.Advance); s < scale {
+			scale = s
+		}
+
+		sf := f.Scale(scale, Bilinear)
+		slot := float64(w) / float64(len(runes)+1)
+		for i, r := range runes {
+			g, _ := sf.Glyph(r)
+			cx := slot*(float64(i)+1) + randFloat(-slot/5, slot/5)
+			cy := float64(h)/2 + randFloat(-float64(h)/10, float64(h)/10)
+			drawRotated(canvas, g.Mask, cx, cy, randFloat(-0.4, 0.4), darkColor())
+		}
+	}
+
+	lines := c.NoiseLines
+	if lines == 0 {
+		lines = 4
+	}
+	for i := 0; i < lines; i++ {
+		drawLine(canvas,
+			randFloat(0, float64(w)/4), randFloat(0, float64(h)),
+			randFloat(float64(w)*3/4, float64(w)), randFloat(0, float64(h)),
+			randFloat(1, 2.5), darkColor())
+	}
+
+	return wave(canvas, randFloat(float64(h)/20, float64(h)/10), randFloat(float64(w)/3, float64(w)), randFloat(0, 2*math.Pi))
+}
+
+func darkColor() color.RGBA {
+	return color.RGBA{uint8(captchaRand.Intn(140)), uint8(captchaRand.Intn(140)), uint8(captchaRand.Intn(140)), 0xff}
+}
+
+// blend paints c with alpha a (0 to 1) over the pixel at x, y
+func blend(canvas *image.RGBA, x, y int, c color.RGBA, a float64) {
+	if !image.Pt(x, y).In(canvas.Rect) || a <= 0 {
+		return
+	}
+
+	if a > 1 {
+		a = 1
+	}
+
+	p := canvas.Pix[canvas.PixOffset(x, y):]
+	p[0] = uint8(float64(p[0])*(1-a) + float64(c.R)*a)
+	p[1] = uint8(float64(p[1])*(1-a) + float64(c.G)*a)
+	p[2] = uint8(float64(p[2])*(1-a) + float64(c.B)*a)
+}
+
+// drawRotated draws the mask rotated by angle (radians) around its center, which is placed at cx, cy
+func drawRotated(canvas *image.RGBA, m *image.Alpha, cx, cy, angle float64, c color.RGBA) {
+	mcx, mcy := float64(m.Rect.Min.X+m.Rect.Max.X)/2, float64(m.Rect.Min.Y+m.Rect.Max.Y)/2
+	radius := math.Hypot(float64(m.Rect.Dx()), float64(m.Rect.Dy()))/2 + 1
+	sin, cos := math.Sincos(-angle)
+
+	for y := int(cy - radius); y <= int(cy+radius); y++ {
+		for x := int(cx - radius); x <= int(cx+radius); x++ {
+			// rotate back into the mask
+			dx, dy := float64(x)+0.5-cx, float64(y)+0.5-cy
+			sx, sy := int(math.Floor(mcx+dx*cos-dy*sin)), int(math.Floor(mcy+dx*sin+dy*cos))
+			if image.Pt(sx, sy).In(m.Rect) {
+				blend(canvas, x, y, c, float64(m.AlphaAt(sx, sy).A)/0xff)
+			}
+		}
+	}
+}
+
+// drawLine draws a line of the given width from x0, y0 to x1, y1
+func drawLine(canvas *image.RGBA, x0, y0, x1, y1, width float64, c color.RGBA) {
+	steps := int(math.Hypot(x1-x0, y1-y0)) + 1
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		x, y := x0+(x1-x0)*t, y0+(y1-y0)*t
+		for d := -width / 2; d <= width/2; d++ {
+			blend(canvas, int(x), int(y+d), c, 0.8)
+		}
+	}
+}
+
+// wave shifts columns vertically along a sine wave
+func wave(src *image.RGBA, amplitude, period, phase float64) *image.RGBA {
+	dst := image.NewRGBA(src.Rect)
+	for x := src.Rect.Min.X; x < src.Rect.Max.X; x++ {
+		shift := int(math.Round(amplitude * math.Sin(2*math.Pi*float64(x)/period+phase)))
+		for y := src.Rect.Min.Y; y < src.Rect.Max.Y; y++ {
+			sy := y - shift
+			if sy < src.Rect.Min.Y {
+				sy = src.Rect.Min.Y
+			} else if sy >= src.Rect.Max.Y {
+				sy = src.Rect.Max.Y - 1
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(x, sy):])
+		}
+	}
+	return dst
+}
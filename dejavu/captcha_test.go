@@ -0,0 +1,96 @@
+package dejavu
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/coyove/common/session"
+)
+
+func TestCaptcha(t *testing.T) {
+	c := &Captcha{}
+	ch, err := c.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(ch.PNG))
+	if err != nil || img.Bounds().Dx() != 160 || img.Bounds().Dy() != 60 {
+		t.Fatal(err)
+	}
+
+	if len(ch.Answer) != 5 || strings.Trim(ch.Answer, CaptchaCharset) != "" {
+		t.Fatal(ch.Answer)
+	}
+
+	if !VerifyCaptcha(ch.Token, " "+strings.ToLower(ch.Answer)) || VerifyCaptcha(ch.Token, ch.Answer) {
+		t.Fatal("token should be verified once")
+	}
+
+	// a failed answer burns the token
+	ch, _ = (&Captcha{Length: 4, Charset: "AB", Width: 100, Height: 40, NoiseLines: -1}).New()
+	if VerifyCaptcha(ch.Token, ch.Answer+"x") || VerifyCaptcha(ch.Token, ch.Answer) || strings.Trim(ch.Answer, "AB") != "" {
+		t.Fatal("token should be burnt")
+	}
+
+	// the token stays burnt however many other tokens fail
+	ch, _ = c.New()
+	if VerifyCaptcha(ch.Token, "") {
+		t.Fatal("wrong answer")
+	}
+	for i := 0; i < 1<<16+1; i++ {
+		VerifyCaptcha(session.NewString(answerHash("x")), "")
+	}
+	if VerifyCaptcha(ch.Token, ch.Answer) {
+		t.Fatal("token should stay burnt")
+	}
+
+	if VerifyCaptcha("not a token", "") {
+		t.Fatal("invalid token")
+	}
+
+	// the text is drawn in dark colors
+	dark := 0
+	rgba := c.Draw("W")
+	for i := 0; i < len(rgba.Pix); i += 4 {
+		if rgba.Pix[i] < 0x90 && rgba.Pix[i+1] < 0x90 && rgba.Pix[i+2] < 0x90 {
+			dark++
+		}
+	}
+	if dark < 100 {
+		t.Fatal(dark)
+	}
+}
+
+func TestCaptchaConcurrent(t *testing.T) {
+	ch, _ := (&Captcha{}).New()
+
+	var wg sync.WaitGroup
+	var accepted, correct int32
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			answer := "wrong"
+			if i%2 == 0 {
+				answer = ch.Answer
+			}
+			if VerifyCaptcha(ch.Token, answer) {
+				atomic.AddInt32(&accepted, 1)
+				if answer == ch.Answer {
+					atomic.AddInt32(&correct, 1)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// only the first attempt counts, right or wrong
+	if accepted > 1 || accepted != correct {
+		t.Fatal(accepted, correct)
+	}
+}
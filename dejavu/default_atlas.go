@@ -0,0 +1,39 @@
+// Code generated by atlasgen -png fonts/default.png -width 7 -ascent 10 -descent 2 -var defaultAtlas -o default_atlas.go; DO NOT EDIT.
+
+package dejavu
+
+// defaultAtlas holds 95 glyphs of 7x12 pixels
+func init() {
+	generatedAtlases["defaultAtlas"] = "" +
+		"xJh7UFTnGcZ/y7LLspKIiRKMCAaMk0k01QQNwSQlCTFGwctELZpIJlviRGqVWIpEo6nW8YLGZhSN01qlBhXNVbROHWnKtJZL" +
+		"jCjKxZUVryiwXEVgZZd9OyxqNKJ7dsX0/WOf+fZ859nn+973fc531kvv8c5jqvsa/JwhXUEzAJR0NdN5CHI73GOkhm4cChxU" +
+		"53iQIB0BMQlvzwLHIAFyVLnAgIyB6a6t/TeXRARaBwnA39MkDFCPkm07AWRIE1gb5MJ0JVuQ0poC0DLyCmhJaVoWuDYWeq9c" +
+		"9jCMWxeU0roC9bXLdFxWJvMziwA0BAF47l8HsH7KxV9YoNnr/X8egLLlD578JYSXXtrw9b3k3c3QZAAw/Xc4tjAUAPODANg8" +
+		"AKjuCcCeEQC89fvO+3bdmXOXJwBJUwEYkQmA70UAPNsA6NU5Cuu8Nq9z5k61M7V/C5D+aRCwQ3b0g/4ZsjMA0vpJwJZubqdr" +
+		"ZdpNm3z/kykiP4/ZKIqIbACyI1wY3DUeO2TLDYSvl2iXZkB9H/yrwM5/PG1Q50ffKvhyuX7lNgj8r+Xf/VzbMqdwoxicRqzJ" +
+		"WvgqfDFEE1MFQOBVNeD3/Srg2dNLVRCXN+IGp48SzjeL2w6/BDue0Ey8COAzrRgQqRkG4B1TBH8N0k+tgbeNbYWvK0zRfAEY" +
+		"Vi2AV9E0AdZkIID9xmpdq8lrt12/qcGyfwBA7xU5AHhbAHouzAGR1v0hijh162tF4E8FgwAqXwDAvqK1YgrUj9ZGmmF3lPdr" +
+		"lRCSaymLVqzTDMCGpQAP1fcFSE4H8Dz/LEDMQQDyJqEsJp2ymyZCY6R2dB0cGa0bewieq5PaZ6FslHZsKTSO0UXVwpRym2m8" +
+		"Is4p52z5IVAXqYn6Fmpf0UWbYdKZ5hQrAJFnAdWQ438EsV9M0XZ3T8t98AnXvU5nyIeQNUcWBYBtgwYgOOXIkkDHtdhc991M" +
+		"bm5p5Q3edeQbdAD9FxWsDgHQbrRC4JKCFY9BbqyXi9LsxgUAdBxfYGhFPIBxchd7plzzwvrq0fCr7/0mn4B/vb64oB0afSui" +
+		"BeweNp1A/cMXxgscmPRxjsD4U4MBmHPprMedOAeLY05ieyJA1qYsoEeTf1MPiBKRKEhNIjkVTIN52qQwLfbyN0FgZB0ImjEn" +
+		"QURa3wBB/YYRBI+XG0Ck/VSMIk7t+vqmeZDylV/PFLgYBHQsGrgxWvlN34c+Ae2aqsZ5CnWaP9OD8Gh6Kgj0rwABbRsIBJ0H" +
+		"8QjK+BSkveJTb1dK8ic9cpcvu5dTcSlp19c3JnbsZ58HV/+4nypE4FJfEFib1utRAf3mRvN8ZToBuQ1+1HkbKF97tz7f735o" +
+		"0NiAFN/oMuC3tZWTFVLCzH06xPGJOD6RygUA0jN3LCAElT8BAm+c6KWQUykor6W5JApIicooIAc/yhaQae0x95KjRBCQEz4O" +
+		"+Gi+Ax4o8xUQkpc4wKfcASQp43y8wPZDMOydp134FTT44mcGO3ScFRt68YgZ9izwXvwFhORbcoIUpr3dOA4EzwnVIGgnd4BY" +
+		"i8a630c36fymK51VkLnAe/F2CM63VfQF0G0b50ynrTgKBM1EswOiToLY5dwgEPS7/wACgTUDECA5U4nOiII20zSonO41YAuY" +
+		"Pg7XAwPX/1D7FgBPVcG2x3XTa2C6yVoU3c29qXxHu/YQQMAOKiuYorXjy+Dl4rbiCEWc5U8y2AQbE0hMhQn/ICsKHmjs3agH" +
+		"slOzAJKtiQBD5WlFlHV+4F9D3gsV5yMOkpZUdjppE8l56Rl5iUywz0qwR/GEhIbJQDRNGq8mtSLSvfHM3gvBxp5nQ4CluSmd" +
+		"ksIBvtySCQw/qj75HGTFYshSxPldHDOyIPzMA+dGAPtyMwGGydB7yZGIWABU3yUBzM7xAAbVDALUuXMBkg92fIVFgS91+Zqi" +
+		"BJwRX38ljch2Y3hHnS4Lda4z8uorwEP5z+T7wtbnCU/j/xXS5ZPk84EAPPyX+//z1qOvAqhGVkDCBbvYwfKabpzAInNtorjz" +
+		"wFxtKQsDMegMx0B06NtAZvT4dQHIqubiUDd0Dj9mF6AkXgtg1wFwfJYDhx22KWmk27SGl1lWCZS+q5shDk4fgeOGHvECYSXN" +
+		"y915tRlyyGaKgKNTNa8ZoV1E2iF/gh7g8QMtTktzZrMAVA25pZ6dm76r8bzJ0rG+4jidoXPt+o61z/R5T2B4acsyG4B6chEg" +
+		"7cYXleX9g4aG90De93rdDKJG5eLZRlwzAUVnMOmK0rmHhJkBpPodpTWPX3YsyFOFLwNytGowIH0mnnoEBN7N83ZiAoo9rnIY" +
+		"AHGX3cq8HN55ehvIk/JUAwiCvRPkBrgc95z3LuJFo710JBgNuhnHO3uzDUpn+MQfg5HH246FuaNzteXkNV8qvMWXCjt9abgb" +
+		"TfVCmWWFQInhZg8p6fSQ50ual7rVrPJJSwEgc7z/DIimG/o94Uy7CFz58EktEL3beHkxgPrVJhCxGg0KleHUl9qCAVjT4l59" +
+		"/vQYqhEwj/aOF4irqYl3h/RoJKMKYHY6GfEd/1sG1/sCuw5vBxglkW5QcmYAweWQFieGTRCbmfftNAhoSWj2B4z9St3h3JDG" +
+		"1lTwPLBunxoIlWcA78I9BTrY/Dnpm9zgzIt0/Ckbs52CKPAsm1SkApKvGAB61+gA9ezVAHLET2mOROQywMyGMGBq7XBgTHUo" +
+		"QLMST95qA8DaBwAB8HilCkDkA8doghkHXq8pfwA2XVVYmW7DLWHdDIB/6y1TzC95AMy1C0BtlGMkKoBHO2de3ehO9lnbuqF3" +
+		"IszRf9iw5q4T/zcA" +
+		""
+}
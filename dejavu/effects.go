@@ -0,0 +1,116 @@
+package dejavu
+
+import (
+	"image"
+	"math"
+)
+
+// Filter is the way glyphs are resampled when scaled
+type Filter int
+
+const (
+	// Nearest keeps pixels sharp, it is exact for integer scales
+	Nearest Filter = iota
+	// Bilinear smooths the edges of glyphs, better for fractional scales
+	Bilinear
+)
+
+// derived returns a font whose glyphs are transformed by fn on first use and whose metrics are
+// transformed by metric, the fallbacks are derived too. Changes made to the fallback chain of f
+// after that are not seen by the derived font.
+func (f *Font) derived(fn func(*Glyph) *Glyph, metric func(int) int) *Font {
+	d := &Font{
+		Ascent:      metric(f.Ascent),
+		Descent:     metric(f.Descent),
+		Replacement: f.Replacement,
+		glyphs:      map[rune]*Glyph{},
+		box:         fn(f.box),
+		src:         f,
+		derive:      fn,
+	}
+
+	if f.Fallback != nil {
+		d.Fallback = f.Fallback.derived(fn, metric)
+	}
+	return d
+}
+
+// Scale returns the font scaled by s, e.g. 2 for double size or 1.5 with Bilinear for smooth text
+func (f *Font) Scale(s float64, filter Filter) *Font {
+	if s <= 0 {
+		panic("dejavu: scale must be positive")
+	}
+
+	metric := func(v int) int { return int(math.Round(float64(v) * s)) }
+	return f.derived(func(g *Glyph) *Glyph {
+		return &Glyph{Mask: scaleMask(g.Mask, s, filter), Advance: metric(g.Advance)}
+	}, metric)
+}
+
+// Bold returns the font emboldened by thickening strokes one pixel to the right
+func (f *Font) Bold() *Font {
+	return f.derived(func(g *Glyph) *Glyph {
+		return &Glyph{Mask: dilate(g.Mask, image.Rect(0, 0, 2, 1)), Advance: g.Advance + 1}
+	}, func(v int) int { return v })
+}
+
+func scaleMask(m *image.Alpha, s float64, filter Filter) *image.Alpha {
+	r := m.Rect
+	dst := image.NewAlpha(image.Rect(
+		int(math.Floor(float64(r.Min.X)*s)), int(math.Floor(float64(r.Min.Y)*s)),
+		int(math.Ceil(float64(r.Max.X)*s)), int(math.Ceil(float64(r.Max.Y)*s)),
+	))
+
+	// at returns the alpha of the source pixel, 0 outside of it
+	at := func(x, y int) float64 {
+		if !image.Pt(x, y).In(r) {
+			return 0
+		}
+		return float64(m.Pix[m.PixOffset(x, y)])
+	}
+
+	for y := dst.Rect.Min.Y; y < dst.Rect.Max.Y; y++ {
+		for x := dst.Rect.Min.X; x < dst.Rect.Max.X; x++ {
+			// center of the pixel in the source
+			sx, sy := (float64(x)+0.5)/s, (float64(y)+0.5)/s
+
+			var a float64
+			if filter == Bilinear {
+				fx, fy := sx-0.5, sy-0.5
+				x0, y0 := int(math.Floor(fx)), int(math.Floor(fy))
+				tx, ty := fx-float64(x0), fy-float64(y0)
+				a = at(x0, y0)*(1-tx)*(1-ty) + at(x0+1, y0)*tx*(1-ty) +
+					at(x0, y0+1)*(1-tx)*ty + at(x0+1, y0+1)*tx*ty
+			} else {
+				a = at(int(math.Floor(sx)), int(math.Floor(sy)))
+			}
+			dst.Pix[dst.PixOffset(x, y)] = uint8(math.Round(a))
+		}
+	}
+	return dst
+}
+
+// dilate returns the mask where each pixel is spread to the offsets in nb,
+// pixels covered by multiple ones take the max alpha
+func dilate(m *image.Alpha, nb image.Rectangle) *image.Alpha {
+	r := m.Rect
+	dst := image.NewAlpha(image.Rectangle{r.Min.Add(nb.Min), r.Max.Add(nb.Max).Sub(image.Pt(1, 1))})
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			a := m.Pix[m.PixOffset(x, y)]
+			if a == 0 {
+				continue
+			}
+
+			for dy := nb.Min.Y; dy < nb.Max.Y; dy++ {
+				for dx := nb.Min.X; dx < nb.Max.X; dx++ {
+					if i := dst.PixOffset(x+dx, y+dy); dst.Pix[i] < a {
+						dst.Pix[i] = a
+					}
+				}
+			}
+		}
+	}
+	return dst
+}
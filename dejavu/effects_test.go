@@ -0,0 +1,108 @@
+package dejavu
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestScale(t *testing.T) {
+	src, _ := Default.Glyph('A')
+
+	f := Default.Scale(2, Nearest)
+	g, ok := f.Glyph('A')
+	if !ok || f.Ascent != 2*Height || g.Advance != 2*Width || g.Mask.Rect != image.Rect(0, -2*Height, 2*Width, 2*(FullHeight-Height)) {
+		t.Fatal(g.Mask.Rect)
+	}
+
+	for y := src.Mask.Rect.Min.Y; y < src.Mask.Rect.Max.Y; y++ {
+		for x := 0; x < Width; x++ {
+			a := src.Mask.AlphaAt(x, y).A
+			if g.Mask.AlphaAt(2*x, 2*y).A != a || g.Mask.AlphaAt(2*x+1, 2*y+1).A != a {
+				t.Fatal("not replicated at", x, y)
+			}
+		}
+	}
+
+	if g2, _ := f.Glyph('A'); g2 != g {
+		t.Fatal("glyph is not cached")
+	}
+
+	f = Default.Scale(1.5, Bilinear)
+	g, _ = f.Glyph('A')
+	partial := false
+	for _, a := range g.Mask.Pix {
+		partial = partial || (a > 0 && a < 0xff)
+	}
+	if f.Ascent != 15 || f.Descent != 3 || g.Advance != 11 || !partial {
+		t.Fatal(f.Ascent, f.Descent, g.Advance, partial)
+	}
+
+	bdf, _ := LoadBDF(strings.NewReader(testBDF))
+	bdf.Fallback = Default
+	f = bdf.Scale(3, Nearest)
+	if g, ok := f.Glyph('x'); !ok || g.Advance != 3*Width || f.Fallback.Ascent != 3*Height {
+		t.Fatal(g)
+	}
+
+	if g, ok := f.Glyph('Ā'); ok || g.Advance != 3*Width {
+		t.Fatal("replacement glyph should be scaled")
+	}
+
+	src, _ = Default.Glyph('|')
+	g, _ = Default.Bold().Glyph('|')
+	if g.Advance != Width+1 {
+		t.Fatal(g.Advance)
+	}
+	for y := src.Mask.Rect.Min.Y; y < src.Mask.Rect.Max.Y; y++ {
+		for x := 0; x < Width; x++ {
+			if a := src.Mask.AlphaAt(x, y).A; a > 0 && (g.Mask.AlphaAt(x+1, y).A < a || g.Mask.AlphaAt(x, y).A < a) {
+				t.Fatal("not emboldened at", x, y)
+			}
+		}
+	}
+}
+
+func TestEffects(t *testing.T) {
+	red, green, blue, white := color.RGBA{0xff, 0, 0, 0xff}, color.RGBA{0, 0xff, 0, 0xff}, color.RGBA{0, 0, 0xff, 0xff}, color.RGBA{0xff, 0xff, 0xff, 0xff}
+	canvas := image.NewRGBA(image.Rect(0, 0, 40, 30))
+
+	l := &Layout{
+		Background:   image.NewUniform(red),
+		Padding:      3,
+		Outline:      image.NewUniform(green),
+		Shadow:       image.NewUniform(blue),
+		ShadowOffset: image.Pt(3, 3),
+	}
+	l.Draw(canvas, "-", image.Rect(5, 5, 35, 25), image.NewUniform(white))
+
+	// "-" is a horizontal bar, find its left end
+	g, _ := Default.Glyph('-')
+	x, y := -1, 0
+	for p := g.Mask.Rect.Min; x < 0; p.X++ {
+		if p.X == g.Mask.Rect.Max.X {
+			p.X, p.Y = g.Mask.Rect.Min.X, p.Y+1
+		}
+		if g.Mask.AlphaAt(p.X, p.Y).A > 0 {
+			x, y = 5+p.X, 5+Height+p.Y
+		}
+	}
+
+	for _, c := range []struct {
+		x, y  int
+		color color.RGBA
+	}{
+		{x, y, white},
+		{x - 1, y, green},
+		{x + 3, y + 3, blue},
+		{5, 5, red},
+		{5 + Width + 2, 5 + FullHeight + 2, red},
+		{2, 2, color.RGBA{}}, // padding is clipped by rect
+		{5 + Width + 3, 5, color.RGBA{}},
+	} {
+		if got := canvas.RGBAAt(c.x, c.y); got != c.color {
+			t.Fatal(c, got)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package dejavu
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"strings"
+	"sync"
+)
+
+// Glyph is the alpha mask of a rune, its bounds are relative to the dot on the baseline,
+// so Min.Y is negative for the pixels above the baseline
+type Glyph struct {
+	Mask    *image.Alpha
+	Advance int
+}
+
+// Font maps runes to glyphs. Runes missing from a font are looked up in its Fallback chain,
+// which must not form a cycle, and runes missing from the whole chain are drawn as the
+// Replacement glyph of the first font having one, or as an empty box if none has.
+type Font struct {
+	Ascent, Descent int // pixels above and below the baseline
+	Replacement     rune
+	Fallback        *Font
+
+	glyphs map[rune]*Glyph
+	box    *Glyph
+
+	// fonts derived by Scale and Bold transform glyphs of src on first use and cache them in glyphs,
+	// generated fonts set src by load on first use
+	src    *Font
+	derive func(*Glyph) *Glyph
+	load   func() *Font
+	once   sync.Once
+	mu     sync.Mutex
+}
+
+// Default is the 7x12 font of the printable ASCII characters
+var Default = generatedFont("defaultAtlas", Height, FullHeight-Height, '?')
+
+// NewFont creates an empty font
+func NewFont(ascent, descent int, replacement rune) *Font {
+	f := &Font{
+		Ascent:      ascent,
+		Descent:     descent,
+		Replacement: replacement,
+		glyphs:      map[rune]*Glyph{},
+	}
+
+	// an empty box of the height of capital letters
+	w, h := (ascent+descent)*3/5, ascent
+	if w < 3 || h < 3 {
+		w, h = 3, 3
+	}
+
+	box := image.NewAlpha(image.Rect(0, -h, w, 0))
+	for x := 0; x < w-1; x++ {
+		box.Pix[box.PixOffset(x, -h)], box.Pix[box.PixOffset(x, -1)] = 0xff, 0xff
+	}
+	for y := -h; y < 0; y++ {
+		box.Pix[box.PixOffset(0, y)], box.Pix[box.PixOffset(w-2, y)] = 0xff, 0xff
+	}
+	f.box = &Glyph{Mask: box, Advance: w}
+	return f
+}
+
+// Add adds or replaces the glyph of r
+func (f *Font) Add(r rune, g *Glyph) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.glyphs[r] = g
+}
+
+// glyph returns the glyph of r in the font itself
+func (f *Font) glyph(r rune) *Glyph {
+	if f.load != nil {
+		f.once.Do(func() { f.src = f.load() })
+	}
+
+	if f.src == nil {
+		return f.glyphs[r]
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	g := f.glyphs[r]
+	if g == nil {
+		if sg := f.src.glyph(r); sg != nil {
+			g = f.derive(sg)
+			f.glyphs[r] = g
+		}
+	}
+	return g
+}
+
+// Glyph returns the glyph of r and whether r is covered by the font or its fallbacks,
+// the replacement glyph is returned if it isn't
+func (f *Font) Glyph(r rune) (*Glyph, bool) {
+	for ff := f; ff != nil; ff = ff.Fallback {
+		if g := ff.glyph(r); g != nil {
+			return g, true
+		}
+	}
+
+	for ff := f; ff != nil; ff = ff.Fallback {
+		if g := ff.glyph(ff.Replacement); g != nil {
+			return g, false
+		}
+	}
+	return f.box, false
+}
+
+// UnsupportedError lists the runes of a text which are not covered by a font, in the order of their first appearance
+type UnsupportedError struct {
+	Runes []rune
+}
+
+func (e *UnsupportedError) Error() string {
+	names := make([]string, len(e.Runes))
+	for i, r := range e.Runes {
+		names[i] = fmt.Sprintf("%#U", r)
+	}
+	return "dejavu: unsupported characters: " + strings.Join(names, ", ")
+}
+
+// Check returns an *UnsupportedError if some runes of text are not covered by the font or its fallbacks
+func (f *Font) Check(text string) error {
+	return f.check(text, nil)
+}
+
+// check is like Check but skips runes for which skip returns true
+func (f *Font) check(text string, skip func(rune) bool) error {
+	var runes []rune
+	seen := map[rune]bool{}
+
+	for _, r := range text {
+		if _, ok := f.Glyph(r); ok || seen[r] || (skip != nil && skip(r)) {
+			continue
+		}
+
+		seen[r] = true
+		runes = append(runes, r)
+	}
+
+	if len(runes) > 0 {
+		return &UnsupportedError{runes}
+	}
+	return nil
+}
+
+// DrawTextStrict is like DrawText but draws nothing and returns an *UnsupportedError
+// if some runes of text are not covered by the font or its fallbacks
+func (f *Font) DrawTextStrict(canvas draw.Image, text string, x, y int, src image.Image) (int, error) {
+	if err := f.Check(text); err != nil {
+		return x, err
+	}
+	return f.DrawText(canvas, text, x, y, src), nil
+}
+
+// DrawText draws text with its baseline at y starting from x and returns the x after the last glyph
+func (f *Font) DrawText(canvas draw.Image, text string, x, y int, src image.Image) int {
+	for _, r := range text {
+		g, _ := f.Glyph(r)
+		g.draw(canvas, x, y, src, canvas.Bounds())
+		x += g.Advance
+	}
+	return x
+}
+
+// draw draws the glyph with its dot at x, y, pixels outside clip are not touched
+func (g *Glyph) draw(canvas draw.Image, x, y int, src image.Image, clip image.Rectangle) {
+	r := g.Mask.Rect.Add(image.Pt(x, y))
+	c := r.Intersect(clip)
+	if c.Empty() {
+		return
+	}
+
+	off := c.Min.Sub(r.Min)
+	draw.DrawMask(canvas, c, src, off, g.Mask, g.Mask.Rect.Min.Add(off), draw.Over)
+}
+
+func runeRange(from, to rune) []rune {
+	res := make([]rune, 0, to-from+1)
+	for r := from; r <= to; r++ {
+		res = append(res, r)
+	}
+	return res
+}
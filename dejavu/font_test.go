@@ -0,0 +1,103 @@
+package dejavu
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+const testBDF = `STARTFONT 2.1
+FONT -test-fixed-medium-r-normal--8-80-75-75-c-80-iso10646-1
+SIZE 8 75 75
+FONTBOUNDINGBOX 8 8 0 -2
+STARTPROPERTIES 2
+FONT_ASCENT 6
+FONT_DESCENT 2
+ENDPROPERTIES
+CHARS 3
+STARTCHAR eacute
+ENCODING 233
+DWIDTH 6 0
+BBX 4 3 1 0
+BITMAP
+F0
+80
+F0
+ENDCHAR
+STARTCHAR uni4E2D
+ENCODING 20013
+DWIDTH 8 0
+BBX 8 8 0 -2
+BITMAP
+10
+FE
+92
+FE
+10
+10
+10
+10
+ENDCHAR
+STARTCHAR unencoded
+ENCODING -1
+BBX 1 1 0 0
+BITMAP
+80
+ENDCHAR
+ENDFONT
+`
+
+func TestFont(t *testing.T) {
+	if g, ok := Default.Glyph('A'); !ok || g.Advance != Width || g.Mask.Bounds() != image.Rect(0, -Height, Width, FullHeight-Height) {
+		t.Fatal(g)
+	}
+
+	if g, ok := Default.Glyph('é'); ok || g != Default.glyphs['?'] {
+		t.Fatal(g)
+	}
+
+	f, err := LoadBDF(strings.NewReader(testBDF))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f.Ascent != 6 || f.Descent != 2 || len(f.glyphs) != 2 {
+		t.Fatal(f)
+	}
+
+	g, ok := f.Glyph('é')
+	if !ok || g.Advance != 6 || g.Mask.Bounds() != image.Rect(1, -3, 5, 0) || g.Mask.AlphaAt(1, -2).A != 0xff || g.Mask.AlphaAt(2, -2).A != 0 {
+		t.Fatal(g)
+	}
+
+	// the replacement of the BDF font is U+FFFD which it doesn't have, so the box is drawn
+	if g, ok := f.Glyph('x'); ok || g != f.box {
+		t.Fatal(g)
+	}
+
+	f.Fallback = Default
+	if g, ok := f.Glyph('x'); !ok || g != Default.glyphs['x'] {
+		t.Fatal(g)
+	}
+
+	if g, ok := f.Glyph('Ā'); ok || g != Default.glyphs['?'] {
+		t.Fatal(g)
+	}
+
+	canvas := image.NewAlpha(image.Rect(0, 0, 40, 12))
+	if x := f.DrawText(canvas, "a中é", 2, 10, image.NewUniform(color.Alpha{0xff})); x != 2+Width+8+6 {
+		t.Fatal(x)
+	}
+
+	// top of 中 is at baseline - ascent
+	if canvas.AlphaAt(2+Width+3, 4).A != 0xff || canvas.AlphaAt(2+Width+3, 3).A != 0 {
+		t.Fatal("glyph is not drawn at the baseline")
+	}
+
+	for _, text := range []string{"STARTFONT 2.1\nCHARS 1\n", testBDF[:200], strings.Replace(testBDF, "FE", "XX", 1)} {
+		if _, err := LoadBDF(strings.NewReader(text)); err == nil {
+			t.Fatal(text)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package dejavu
+
+// Atlases are generated from the sources in the fonts directory. default.png holds the glyphs of Default
+// drawn white on black, DejaVuSansMono-Bold.ttf is from the DejaVu fonts, see fonts/LICENSE.
+//go:generate go run ../cmd/atlasgen -png fonts/default.png -width 7 -ascent 10 -descent 2 -var defaultAtlas -o default_atlas.go
+//go:generate go run ../cmd/atlasgen -ttf fonts/DejaVuSansMono-Bold.ttf -size 10.8 -ascent 10 -descent 2 -var boldAtlas -o bold_atlas.go
+
+// DefaultBold is the bold version of Default rasterized from DejaVu Sans Mono Bold, glyphs have the same size
+var DefaultBold = generatedFont("boldAtlas", Height, FullHeight-Height, '?')
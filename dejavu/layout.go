@@ -0,0 +1,234 @@
+package dejavu
+
+import (
+	"image"
+	"image/draw"
+	"strings"
+	"unicode"
+)
+
+// Align is the horizontal alignment of lines
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignCenter
+	AlignRight
+)
+
+// Layout lays text out in lines, "\n" and "\r\n" start new lines and tabs advance to the next tab stop.
+// Lines are wrapped at spaces and around CJK characters, words wider than a line are broken anywhere.
+type Layout struct {
+	Font        *Font // nil means Default
+	Align       Align
+	LineSpacing int  // extra pixels between lines
+	TabWidth    int  // distance of tab stops in spaces, 0 means 4
+	NoWrap      bool // lines are clipped instead of wrapped by Draw
+
+	// effects drawn by Draw under the text, nil images mean none
+	Outline      image.Image // stroke around glyphs
+	OutlineWidth int         // 0 means 1
+	Shadow       image.Image // glyphs drawn under the text at ShadowOffset
+	ShadowOffset image.Point // (0, 0) means (1, 1)
+	Background   image.Image // box filling the bounds of the text grown by Padding
+	Padding      int
+}
+
+// MeasureText returns the size of text drawn by DrawText
+func MeasureText(text string) image.Point {
+	return Default.MeasureText(text)
+}
+
+// MeasureText returns the size of text drawn in the font, text can have multiple lines
+func (f *Font) MeasureText(text string) image.Point {
+	return (&Layout{Font: f}).Measure(text, 0)
+}
+
+func (l *Layout) font() *Font {
+	if l.Font == nil {
+		return Default
+	}
+	return l.Font
+}
+
+// LineHeight returns the distance between the baselines of two lines
+func (l *Layout) LineHeight() int {
+	f := l.font()
+	return f.Ascent + f.Descent + l.LineSpacing
+}
+
+// advance returns the x after drawing r at x, x is relative to the start of the line
+func (l *Layout) advance(x int, r rune) int {
+	f := l.font()
+	if r == '\t' {
+		tab := l.TabWidth
+		if tab <= 0 {
+			tab = 4
+		}
+
+		g, _ := f.Glyph(' ')
+		if tab *= g.Advance; tab <= 0 {
+			return x
+		}
+		return (x/tab + 1) * tab
+	}
+
+	g, _ := f.Glyph(r)
+	return x + g.Advance
+}
+
+func (l *Layout) lineWidth(line string) int {
+	x := 0
+	for _, r := range line {
+		x = l.advance(x, r)
+	}
+	return x
+}
+
+// Lines splits text into lines, lines are wrapped to fit in width if it is positive
+func (l *Layout) Lines(text string, width int) []string {
+	paras := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if width <= 0 {
+		return paras
+	}
+
+	var lines []string
+	for _, p := range paras {
+		lines = append(lines, l.wrap([]rune(p), width)...)
+	}
+	return lines
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t'
+}
+
+// isWide reports whether lines can be broken before and after r
+func isWide(r rune) bool {
+	return r >= 0x2e80 && unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// wrap breaks a paragraph into lines no wider than width, spaces at the breaks are removed
+func (l *Layout) wrap(runes []rune, width int) []string {
+	lines := []string{}
+	for start := 0; ; {
+		// the line can end at brk and the next one starts at next
+		x, end, brk, next := 0, start, -1, -1
+		for ; end < len(runes); end++ {
+			r := runes[end]
+			nx := l.advance(x, r)
+
+			if isSpace(r) {
+				// spaces can exceed the width, they will be removed at the break
+				if end == start || !isSpace(runes[end-1]) {
+					brk = end
+				}
+				next = end + 1
+			} else if end > start {
+				if isWide(r) || isWide(runes[end-1]) {
+					brk, next = end, end
+				}
+				if nx > width {
+					break
+				}
+			}
+			x = nx
+		}
+
+		if end == len(runes) {
+			return append(lines, string(runes[start:]))
+		}
+
+		if brk <= start {
+			// no chance to break, break the word right here
+			brk, next = end, end
+		}
+
+		lines = append(lines, string(runes[start:brk]))
+		start = next
+	}
+}
+
+// Check is like Font.Check but accepts line breaks and tabs
+func (l *Layout) Check(text string) error {
+	return l.font().check(text, func(r rune) bool { return r == '\n' || r == '\r' || r == '\t' })
+}
+
+// Measure returns the size of text laid out in lines no wider than width, or without wrapping if width is 0
+func (l *Layout) Measure(text string, width int) image.Point {
+	lines := l.Lines(text, width)
+	size := image.Pt(0, len(lines)*l.LineHeight()-l.LineSpacing)
+	for _, line := range lines {
+		if w := l.lineWidth(line); w > size.X {
+			size.X = w
+		}
+	}
+	return size
+}
+
+// Draw draws text into rect with lines wrapped to its width, pixels outside rect (including effects) are clipped.
+// It returns false if some lines don't fit in the height of rect.
+func (l *Layout) Draw(canvas draw.Image, text string, rect image.Rectangle, src image.Image) bool {
+	width := rect.Dx()
+	if l.NoWrap {
+		width = 0
+	}
+
+	f, clip := l.font(), rect.Intersect(canvas.Bounds())
+	lines := l.Lines(text, width)
+
+	// each calls fn with every glyph and its dot, bounds are the bounds of lines
+	var bounds image.Rectangle
+	each := func(fn func(g *Glyph, x, y int)) {
+		y := rect.Min.Y + f.Ascent
+		for _, line := range lines {
+			w := l.lineWidth(line)
+			x := rect.Min.X
+			switch l.Align {
+			case AlignCenter:
+				x += (rect.Dx() - w) / 2
+			case AlignRight:
+				x += rect.Dx() - w
+			}
+			bounds = bounds.Union(image.Rect(x, y-f.Ascent, x+w, y+f.Descent))
+
+			lx := 0
+			for _, r := range line {
+				if r != '\t' && fn != nil {
+					g, _ := f.Glyph(r)
+					fn(g, x+lx, y)
+				}
+				lx = l.advance(lx, r)
+			}
+			y += l.LineHeight()
+		}
+	}
+
+	if l.Background != nil {
+		each(nil)
+		box := bounds.Inset(-l.Padding).Intersect(clip)
+		draw.Draw(canvas, box, l.Background, box.Min, draw.Over)
+	}
+
+	if l.Shadow != nil {
+		off := l.ShadowOffset
+		if off == (image.Point{}) {
+			off = image.Pt(1, 1)
+		}
+		each(func(g *Glyph, x, y int) { g.draw(canvas, x+off.X, y+off.Y, l.Shadow, clip) })
+	}
+
+	if l.Outline != nil {
+		w := l.OutlineWidth
+		if w <= 0 {
+			w = 1
+		}
+		each(func(g *Glyph, x, y int) {
+			o := &Glyph{Mask: dilate(g.Mask, image.Rect(-w, -w, w+1, w+1))}
+			o.draw(canvas, x, y, l.Outline, clip)
+		})
+	}
+
+	each(func(g *Glyph, x, y int) { g.draw(canvas, x, y, src, clip) })
+	return bounds.Max.Y <= rect.Max.Y
+}
@@ -0,0 +1,59 @@
+package dejavu
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestLayout(t *testing.T) {
+	if size := MeasureText("ab\r\nc\td"); size != image.Pt(5*Width, 2*FullHeight) {
+		t.Fatal(size)
+	}
+
+	l := &Layout{LineSpacing: 2}
+	for _, c := range []struct {
+		text  string
+		width int
+		lines []string
+	}{
+		{"hello world foo", 11 * Width, []string{"hello world", "foo"}},
+		{"hello   world", 7 * Width, []string{"hello", "world"}},
+		{"abcdefgh", 3 * Width, []string{"abc", "def", "gh"}},
+		{"a\n\nb c", 2 * Width, []string{"a", "", "b", "c"}},
+		{"中文字符", 2 * Width, []string{"中文", "字符"}},
+		{"go中文", 3 * Width, []string{"go中", "文"}},
+		{"", 10, []string{""}},
+	} {
+		if lines := l.Lines(c.text, c.width); !reflect.DeepEqual(lines, c.lines) {
+			t.Fatalf("%q: %q", c.text, lines)
+		}
+	}
+
+	if size := l.Measure("hello world foo", 11*Width); size != image.Pt(11*Width, 2*FullHeight+2) {
+		t.Fatal(size)
+	}
+
+	src := image.NewUniform(color.Alpha{0xff})
+	canvas := image.NewAlpha(image.Rect(0, 0, 60, 40))
+	box := image.Rect(10, 5, 10+5*Width, 5+FullHeight*2)
+
+	l = &Layout{Align: AlignRight}
+	if !l.Draw(canvas, "| |", box, src) || l.Draw(canvas, "aaa bbb ccc", box, src) {
+		t.Fatal("fit")
+	}
+
+	for y := 0; y < canvas.Rect.Dy(); y++ {
+		for x := 0; x < canvas.Rect.Dx(); x++ {
+			if canvas.AlphaAt(x, y).A != 0 && !image.Pt(x, y).In(box) {
+				t.Fatal("not clipped", x, y)
+			}
+		}
+	}
+
+	// "|" is drawn at the middle of its cell, right-aligned "| |" ends at the right of the box
+	if canvas.AlphaAt(box.Max.X-Width/2-1, box.Min.Y+5).A == 0 || canvas.AlphaAt(box.Min.X+Width+3, box.Min.Y+5).A != 0 {
+		t.Fatal("not right-aligned")
+	}
+}
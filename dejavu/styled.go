@@ -0,0 +1,358 @@
+package dejavu
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"strconv"
+	"strings"
+)
+
+// Style is the look of a span of text, nil colors mean the defaults of the drawing function
+type Style struct {
+	Color, Background color.Color
+	Bold              bool
+	Underline         bool
+	Strikethrough     bool
+	Inverse           bool // Color and Background are swapped
+}
+
+// Span is a piece of text drawn in one style
+type Span struct {
+	Text string
+	Style
+}
+
+// ANSIPalette is the 16 basic colors of xterm used by ParseANSI
+var ANSIPalette = color.Palette{
+	color.RGBA{0x00, 0x00, 0x00, 0xff}, color.RGBA{0xcd, 0x00, 0x00, 0xff},
+	color.RGBA{0x00, 0xcd, 0x00, 0xff}, color.RGBA{0xcd, 0xcd, 0x00, 0xff},
+	color.RGBA{0x00, 0x00, 0xee, 0xff}, color.RGBA{0xcd, 0x00, 0xcd, 0xff},
+	color.RGBA{0x00, 0xcd, 0xcd, 0xff}, color.RGBA{0xe5, 0xe5, 0xe5, 0xff},
+	color.RGBA{0x7f, 0x7f, 0x7f, 0xff}, color.RGBA{0xff, 0x00, 0x00, 0xff},
+	color.RGBA{0x00, 0xff, 0x00, 0xff}, color.RGBA{0xff, 0xff, 0x00, 0xff},
+	color.RGBA{0x5c, 0x5c, 0xff, 0xff}, color.RGBA{0xff, 0x00, 0xff, 0xff},
+	color.RGBA{0x00, 0xff, 0xff, 0xff}, color.RGBA{0xff, 0xff, 0xff, 0xff},
+}
+
+// ParseANSI splits text into spans styled by its SGR escape sequences, e.g. "\x1b[1;31m",
+// including 256 colors and 24-bit colors. Other escape sequences are removed.
+// Colors 0 to 15 are taken from palette, colors missing from it are taken from ANSIPalette.
+func ParseANSI(text string, palette color.Palette) []Span {
+	if len(palette) < len(ANSIPalette) {
+		palette = append(palette[:len(palette):len(palette)], ANSIPalette[len(palette):]...)
+	}
+
+	var spans []Span
+	var style Style
+	buf := &strings.Builder{}
+
+	flush := func() {
+		if buf.Len() == 0 {
+			return
+		}
+		if n := len(spans); n > 0 && spans[n-1].Style == style {
+			spans[n-1].Text += buf.String()
+		} else {
+			spans = append(spans, Span{buf.String(), style})
+		}
+		buf.Reset()
+	}
+
+	for i := 0; i < len(text); i++ {
+		if text[i] != 0x1b {
+			buf.WriteByte(text[i])
+			continue
+		}
+
+		if i+1 >= len(text) {
+			break
+		}
+
+		switch text[i+1] {
+		case '[':
+			// CSI: parameters end at a byte in 0x40 to 0x7e
+			j := i + 2
+			for j < len(text) && (text[j] < 0x40 || text[j] > 0x7e) {
+				j++
+			}
+			if j == len(text) {
+				i = j
+				break
+			}
+			if text[j] == 'm' {
+				flush()
+				style = applySGR(style, text[i+2:j], palette)
+			}
+			i = j
+		case ']':
+			// OSC: ends at BEL or ST
+			j := i + 2
+			for j < len(text) && text[j] != 0x07 && !(text[j] == 0x1b && j+1 < len(text) && text[j+1] == '\\') {
+				j++
+			}
+			if j < len(text) && text[j] == 0x1b {
+				j++
+			}
+			i = j
+		default:
+			i++
+		}
+	}
+
+	flush()
+	return spans
+}
+
+// applySGR applies the parameters of a "Select Graphic Rendition" sequence to s
+func applySGR(s Style, params string, palette color.Palette) Style {
+	var ps []int
+	for _, p := range strings.FieldsFunc(params, func(r rune) bool { return r == ';' || r == ':' }) {
+		v, _ := strconv.Atoi(p)
+		ps = append(ps, v)
+	}
+	if len(ps) == 0 {
+		ps = []int{0}
+	}
+
+	for i := 0; i < len(ps); i++ {
+		switch p := ps[i]; {
+		case p == 0:
+			s = Style{}
+		case p == 1:
+			s.Bold = true
+		case p == 4:
+			s.Underline = true
+		case p == 7:
+			s.Inverse = true
+		case p == 9:
+			s.Strikethrough = true
+		case p == 22:
+			s.Bold = false
+		case p == 24:
+			s.Underline = false
+		case p == 27:
+			s.Inverse = false
+		case p == 29:
+			s.Strikethrough = false
+		case p >= 30 && p <= 37:
+			s.Color = palette[p-30]
+		case p == 39:
+			s.Color = nil
+		case p >= 40 && p <= 47:
+			s.Background = palette[p-40]
+		case p == 49:
+			s.Background = nil
+		case p >= 90 && p <= 97:
+			s.Color = palette[p-90+8]
+		case p >= 100 && p <= 107:
+			s.Background = palette[p-100+8]
+		case p == 38 || p == 48:
+			// 38;5;n for 256 colors and 38;2;r;g;b for 24-bit colors
+			var c color.Color
+			if i+2 < len(ps) && ps[i+1] == 5 {
+				c, i = color256(ps[i+2], palette), i+2
+			} else if i+4 < len(ps) && ps[i+1] == 2 {
+				c, i = color.RGBA{uint8(ps[i+2]), uint8(ps[i+3]), uint8(ps[i+4]), 0xff}, i+4
+			} else {
+				return s
+			}
+
+			if p == 38 {
+				s.Color = c
+			} else {
+				s.Background = c
+			}
+		}
+	}
+	return s
+}
+
+// color256 returns the color of xterm 256 colors
+func color256(n int, palette color.Palette) color.Color {
+	switch {
+	case n < 16:
+		return palette[n&15]
+	case n < 232:
+		n -= 16
+		level := func(v int) uint8 {
+			if v == 0 {
+				return 0
+			}
+			return uint8(55 + v*40)
+		}
+		return color.RGBA{level(n / 36 % 6), level(n / 6 % 6), level(n % 6), 0xff}
+	default:
+		v := uint8(8 + (n-232)*10)
+		return color.RGBA{v, v, v, 0xff}
+	}
+}
+
+// bold returns the bold version of the font
+func (f *Font) bold() *Font {
+	if f == Default {
+		return DefaultBold
+	}
+	return f.Bold()
+}
+
+// spanFont returns the font of s, bold is the bold version of f created on demand
+func (f *Font) spanFont(s Style, bold **Font) *Font {
+	if !s.Bold {
+		return f
+	}
+	if *bold == nil {
+		*bold = f.bold()
+	}
+	return *bold
+}
+
+// MeasureSpans returns the width of spans drawn on one line by DrawSpans
+func (f *Font) MeasureSpans(spans []Span) int {
+	var bold *Font
+	x := 0
+	for _, s := range spans {
+		ff := f.spanFont(s.Style, &bold)
+		for _, r := range s.Text {
+			g, _ := ff.Glyph(r)
+			x += g.Advance
+		}
+	}
+	return x
+}
+
+// DrawSpans draws spans on one line with its baseline at y starting from x and returns the x after the last glyph.
+// Nil colors of spans are taken from def, the background is not drawn if it is still nil.
+func (f *Font) DrawSpans(canvas draw.Image, spans []Span, x, y int, def Style) int {
+	var bold *Font
+	clip := canvas.Bounds()
+	thick := (f.Ascent + f.Descent + 6) / 12
+
+	for _, s := range spans {
+		fg, bg := s.Color, s.Background
+		if fg == nil {
+			fg = def.Color
+		}
+		if bg == nil {
+			bg = def.Background
+		}
+		if s.Inverse {
+			fg, bg = bg, fg
+		}
+		if fg == nil {
+			fg = color.Black
+		}
+
+		ff := f.spanFont(s.Style, &bold)
+		x0 := x
+		for _, r := range s.Text {
+			g, _ := ff.Glyph(r)
+			x += g.Advance
+		}
+
+		if bg != nil {
+			box := image.Rect(x0, y-f.Ascent, x, y+f.Descent).Intersect(clip)
+			draw.Draw(canvas, box, image.NewUniform(bg), image.Point{}, draw.Over)
+		}
+
+		src := image.NewUniform(fg)
+		ff.DrawText(canvas, s.Text, x0, y, src)
+
+		if s.Underline {
+			uy := y + 1
+			if f.Descent < 2 {
+				uy = y + f.Descent - thick
+			}
+			draw.Draw(canvas, image.Rect(x0, uy, x, uy+thick).Intersect(clip), src, image.Point{}, draw.Over)
+		}
+		if s.Strikethrough {
+			sy := y - (f.Ascent+1)/3
+			draw.Draw(canvas, image.Rect(x0, sy, x, sy+thick).Intersect(clip), src, image.Point{}, draw.Over)
+		}
+	}
+	return x
+}
+
+// Terminal renders text with ANSI escape sequences like a terminal, e.g. for screenshots of colored logs
+type Terminal struct {
+	Font       *Font       // nil means Default
+	Foreground color.Color // nil means the light gray of ANSIPalette
+	Background color.Color // nil means black
+	Palette    color.Palette
+	TabWidth   int // 0 means 8
+	Padding    int // pixels around the text
+}
+
+// Render draws text into an image fitting its lines, lines are not wrapped
+func (t *Terminal) Render(text string) *image.RGBA {
+	f, tab := t.Font, t.TabWidth
+	if f == nil {
+		f = Default
+	}
+	if tab <= 0 {
+		tab = 8
+	}
+
+	def := Style{Color: t.Foreground, Background: t.Background}
+	if def.Color == nil {
+		def.Color = ANSIPalette[7]
+	}
+	if def.Background == nil {
+		def.Background = color.Black
+	}
+
+	lines := splitSpans(ParseANSI(text, t.Palette), tab)
+	width := 0
+	for _, line := range lines {
+		if w := f.MeasureSpans(line); w > width {
+			width = w
+		}
+	}
+
+	lh := f.Ascent + f.Descent
+	canvas := image.NewRGBA(image.Rect(0, 0, width+t.Padding*2, len(lines)*lh+t.Padding*2))
+	draw.Draw(canvas, canvas.Rect, image.NewUniform(def.Background), image.Point{}, draw.Src)
+
+	for i, line := range lines {
+		f.DrawSpans(canvas, line, t.Padding, t.Padding+i*lh+f.Ascent, def)
+	}
+	return canvas
+}
+
+// splitSpans splits spans into lines and expands tabs into spaces by counting runes as cells
+func splitSpans(spans []Span, tab int) [][]Span {
+	lines := [][]Span{nil}
+	col := 0
+	for _, s := range spans {
+		parts := strings.Split(s.Text, "\n")
+		for i, p := range parts {
+			if i > 0 {
+				lines, col = append(lines, nil), 0
+			}
+
+			buf := &strings.Builder{}
+			for _, r := range p {
+				switch r {
+				case '\r':
+				case '\t':
+					n := tab - col%tab
+					buf.WriteString(strings.Repeat(" ", n))
+					col += n
+				default:
+					buf.WriteRune(r)
+					col++
+				}
+			}
+
+			if buf.Len() > 0 {
+				n := len(lines) - 1
+				lines[n] = append(lines[n], Span{buf.String(), s.Style})
+			}
+		}
+	}
+
+	if len(lines) > 1 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,82 @@
+package dejavu
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestParseANSI(t *testing.T) {
+	red, blue := ANSIPalette[1], ANSIPalette[12]
+	spans := ParseANSI("\x1b]0;title\x07a\x1b[1;31mb\x1b[4mc\x1b[Kd\x1b[0m\x1b[mefg\x1b[94;41mh\x1b[39;49;7mi\x1b[27m\x1b", nil)
+	expect := []Span{
+		{"a", Style{}},
+		{"b", Style{Color: red, Bold: true}},
+		{"cd", Style{Color: red, Bold: true, Underline: true}},
+		{"efg", Style{}},
+		{"h", Style{Color: blue, Background: red}},
+		{"i", Style{Inverse: true}},
+	}
+
+	if len(spans) != len(expect) {
+		t.Fatal(spans)
+	}
+	for i := range spans {
+		if spans[i] != expect[i] {
+			t.Fatal(i, spans[i])
+		}
+	}
+
+	// short palettes are completed by ANSIPalette
+	spans = ParseANSI("\x1b[31ma\x1b[91mb\x1b[38;5;9mc", color.Palette{color.White, color.White})
+	if len(spans) != 2 || spans[0].Color != color.White || spans[1].Text != "bc" || spans[1].Color != ANSIPalette[9] {
+		t.Fatal(spans)
+	}
+
+	spans = ParseANSI("\x1b[38;5;196;48;5;244mx\x1b[38;2;1;2;3;9my\x1b[38;5;3mz", nil)
+	if len(spans) != 3 ||
+		spans[0].Color != (color.RGBA{0xff, 0, 0, 0xff}) || spans[0].Background != (color.RGBA{128, 128, 128, 0xff}) ||
+		spans[1].Color != (color.RGBA{1, 2, 3, 0xff}) || !spans[1].Strikethrough ||
+		spans[2].Color != ANSIPalette[3] {
+		t.Fatal(spans)
+	}
+}
+
+func TestTerminal(t *testing.T) {
+	term := &Terminal{Padding: 2}
+	img := term.Render("ab\tc\n\x1b[4;42m__\x1b[0m\n")
+
+	// "ab" is followed by 6 spaces to the tab stop
+	if img.Rect != image.Rect(0, 0, 9*Width+4, 2*FullHeight+4) {
+		t.Fatal(img.Rect)
+	}
+
+	if img.RGBAAt(0, 0) != (color.RGBA{0, 0, 0, 0xff}) {
+		t.Fatal(img.RGBAAt(0, 0))
+	}
+
+	// the green background of the second line and its underline in the default color
+	top, baseline := 2+FullHeight, 2+FullHeight+Height
+	if img.RGBAAt(3, top) != ANSIPalette[2] || img.RGBAAt(2+2*Width, top) != (color.RGBA{0, 0, 0, 0xff}) {
+		t.Fatal(img.RGBAAt(3, top))
+	}
+	if img.RGBAAt(3, baseline+1) != ANSIPalette[7] {
+		t.Fatal(img.RGBAAt(3, baseline+1))
+	}
+
+	// bold glyphs are taken from DefaultBold
+	canvas := image.NewRGBA(image.Rect(0, 0, Width, FullHeight))
+	Default.DrawSpans(canvas, []Span{{"A", Style{Bold: true}}}, 0, Height, Style{Color: color.White})
+	g, _ := DefaultBold.Glyph('A')
+	for y := 0; y < FullHeight; y++ {
+		for x := 0; x < Width; x++ {
+			if a := g.Mask.AlphaAt(x, y-Height).A; canvas.RGBAAt(x, y).A != a {
+				t.Fatal(x, y, a)
+			}
+		}
+	}
+
+	if w := Default.MeasureSpans([]Span{{"ab", Style{}}, {"c", Style{Bold: true}}}); w != 3*Width {
+		t.Fatal(w)
+	}
+}
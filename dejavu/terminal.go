@@ -0,0 +1,183 @@
+package dejavu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+)
+
+// ASCIIOptions controls ASCIIArt
+type ASCIIOptions struct {
+	Columns int    // number of characters per line, 0 means 80
+	Charset string // characters to choose from, empty means all printable ASCII
+	Invert  bool   // dark pixels are ink, for terminals with light backgrounds
+	Color   bool   // color characters with 24-bit ANSI escape sequences
+}
+
+// ASCIIArt renders img as text. The image is divided into cells of the aspect of the default font,
+// each cell is drawn as the character whose glyph mask is closest to the brightness of its pixels.
+func ASCIIArt(img image.Image, opts *ASCIIOptions) string {
+	if opts == nil {
+		opts = &ASCIIOptions{}
+	}
+
+	cols, charset := opts.Columns, opts.Charset
+	if cols <= 0 {
+		cols = 80
+	}
+	if charset == "" {
+		charset = string(runeRange(0x20, 0x7e))
+	}
+
+	// glyph masks as intensities of the Width x FullHeight cells
+	type candidate struct {
+		r    rune
+		cell []float64
+	}
+	var candidates []candidate
+	for _, r := range charset {
+		if g, ok := Default.Glyph(r); ok {
+			candidates = append(candidates, candidate{r, sampleMask(g.Mask)})
+		}
+	}
+
+	b := img.Bounds()
+	cw := float64(b.Dx()) / float64(cols)
+	ch := cw * FullHeight / Width
+	rows := int(float64(b.Dy())/ch + 0.5)
+	if rows < 1 {
+		rows = 1
+	}
+
+	buf := &strings.Builder{}
+	cell := make([]float64, Width*FullHeight)
+	for row := 0; row < rows; row++ {
+		var last color.RGBA
+		for col := 0; col < cols; col++ {
+			x0, y0 := float64(b.Min.X)+float64(col)*cw, float64(b.Min.Y)+float64(row)*ch
+			c := sampleCell(img, x0, y0, cw, ch, opts.Invert, cell)
+
+			best, bestDist := ' ', -1.0
+			for _, cand := range candidates {
+				d := 0.0
+				for i, v := range cand.cell {
+					d += (v - cell[i]) * (v - cell[i])
+				}
+				if bestDist < 0 || d < bestDist {
+					best, bestDist = cand.r, d
+				}
+			}
+
+			if opts.Color && (col == 0 || c != last) {
+				fmt.Fprintf(buf, "\x1b[38;2;%d;%d;%dm", c.R, c.G, c.B)
+				last = c
+			}
+			buf.WriteRune(best)
+		}
+
+		if opts.Color {
+			buf.WriteString("\x1b[0m")
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
+// sampleMask returns the alpha of the glyph in its Width x FullHeight cell as intensities in [0, 1]
+func sampleMask(m *image.Alpha) []float64 {
+	res := make([]float64, Width*FullHeight)
+	for y := 0; y < FullHeight; y++ {
+		for x := 0; x < Width; x++ {
+			p := image.Pt(x, y-Height)
+			if p.In(m.Rect) {
+				res[y*Width+x] = float64(m.AlphaAt(p.X, p.Y).A) / 0xff
+			}
+		}
+	}
+	return res
+}
+
+// sampleCell averages the pixels of the cell at x0, y0 into Width x FullHeight intensities of ink,
+// and returns the color of the cell weighted by ink
+func sampleCell(img image.Image, x0, y0, cw, ch float64, invert bool, res []float64) color.RGBA {
+	var sr, sg, sb, sw, ar, ag, ab, n float64
+	for gy := 0; gy < FullHeight; gy++ {
+		for gx := 0; gx < Width; gx++ {
+			// pixels covered by the sub-cell, at least one
+			px0, py0 := int(x0+float64(gx)*cw/Width), int(y0+float64(gy)*ch/FullHeight)
+			px1, py1 := int(x0+float64(gx+1)*cw/Width), int(y0+float64(gy+1)*ch/FullHeight)
+			if px1 <= px0 {
+				px1 = px0 + 1
+			}
+			if py1 <= py0 {
+				py1 = py0 + 1
+			}
+
+			var sum, cnt float64
+			for y := py0; y < py1; y++ {
+				for x := px0; x < px1; x++ {
+					if !image.Pt(x, y).In(img.Bounds()) {
+						continue
+					}
+
+					r, g, b, _ := img.At(x, y).RGBA()
+					fr, fg, fb := float64(r)/0xffff, float64(g)/0xffff, float64(b)/0xffff
+					ink := 0.299*fr + 0.587*fg + 0.114*fb
+					if invert {
+						ink = 1 - ink
+					}
+
+					sum, cnt = sum+ink, cnt+1
+					sr, sg, sb, sw = sr+fr*ink, sg+fg*ink, sb+fb*ink, sw+ink
+					ar, ag, ab, n = ar+fr, ag+fg, ab+fb, n+1
+				}
+			}
+
+			res[gy*Width+gx] = 0
+			if cnt > 0 {
+				res[gy*Width+gx] = sum / cnt
+			}
+		}
+	}
+
+	if sw > 0 {
+		sr, sg, sb = sr/sw, sg/sw, sb/sw
+	} else if n > 0 {
+		sr, sg, sb = ar/n, ag/n, ab/n
+	}
+	return color.RGBA{uint8(sr*0xff + 0.5), uint8(sg*0xff + 0.5), uint8(sb*0xff + 0.5), 0xff}
+}
+
+// Banner renders text as block characters for terminals, each character stands for two pixels stacked
+// vertically so the text keeps its proportions. Lines of text are laid out by Layout with the given font.
+func Banner(text string, f *Font) string {
+	l := &Layout{Font: f, NoWrap: true}
+	size := l.Measure(text, 0)
+	if size.X <= 0 || size.Y <= 0 {
+		return ""
+	}
+
+	canvas := image.NewAlpha(image.Rectangle{Max: size})
+	l.Draw(canvas, text, canvas.Rect, image.NewUniform(color.Alpha{0xff}))
+
+	on := func(x, y int) bool { return canvas.AlphaAt(x, y).A >= 0x80 }
+	blocks := [4]rune{' ', '▄', '▀', '█'}
+
+	var lines []string
+	for y := 0; y < size.Y; y += 2 {
+		line := make([]rune, size.X)
+		for x := range line {
+			i := 0
+			if on(x, y) {
+				i |= 2
+			}
+			if on(x, y+1) {
+				i |= 1
+			}
+			line[x] = blocks[i]
+		}
+		lines = append(lines, strings.TrimRight(string(line), " "))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
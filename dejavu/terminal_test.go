@@ -0,0 +1,49 @@
+package dejavu
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+	"testing"
+)
+
+func TestASCIIArt(t *testing.T) {
+	text := "Hi, dejavu!"
+	img := image.NewRGBA(image.Rect(0, 0, len(text)*Width, FullHeight))
+	DrawText(img, text, 0, Height, image.NewUniform(color.RGBA{0xff, 0x80, 0, 0xff}))
+
+	if art := ASCIIArt(img, &ASCIIOptions{Columns: len(text)}); art != text+"\n" {
+		t.Fatalf("%q", art)
+	}
+
+	// twice as large, dark text on white
+	big := image.NewRGBA(image.Rect(0, 0, len(text)*Width*2, FullHeight*2))
+	draw.Draw(big, big.Rect, image.White, image.Point{}, draw.Src)
+	(&Layout{Font: Default.Scale(2, Nearest)}).Draw(big, text, big.Rect, image.Black)
+	if art := ASCIIArt(big, &ASCIIOptions{Columns: len(text), Invert: true}); art != text+"\n" {
+		t.Fatalf("%q", art)
+	}
+
+	art := ASCIIArt(img, &ASCIIOptions{Columns: 2, Charset: " #", Color: true})
+	if !strings.HasPrefix(art, "\x1b[38;2;") || !strings.HasSuffix(art, "\x1b[0m\n") || strings.Count(art, "m") != 3 {
+		t.Fatalf("%q", art)
+	}
+}
+
+func TestBanner(t *testing.T) {
+	b := Banner("|\n-", Default)
+	lines := strings.Split(strings.TrimSuffix(b, "\n"), "\n")
+	if len(lines) != FullHeight {
+		t.Fatalf("%q", b)
+	}
+
+	// "|" is a vertical bar and "-" a horizontal one
+	if strings.Trim(lines[2], " ") != "█" || strings.Trim(lines[FullHeight/2+3], " ") != "▄▄▄" {
+		t.Fatalf("%q", b)
+	}
+
+	if Banner("", Default) != "" {
+		t.Fatal("empty")
+	}
+}
@@ -3,14 +3,16 @@ module github.com/coyove/common
 go 1.20
 
 require (
+	github.com/BurntSushi/toml v0.3.1
 	github.com/gomodule/redigo v2.0.0+incompatible
 	github.com/mitchellh/go-ps v1.0.0
 	github.com/sirupsen/logrus v1.8.1
+	golang.org/x/image v0.18.0
 	gopkg.in/natefinch/lumberjack.v2 v2.0.0
+	gopkg.in/yaml.v2 v2.4.0
 )
 
 require (
-	github.com/BurntSushi/toml v0.3.1 // indirect
 	golang.org/x/sys v0.0.0-20191026070338-33540a1f6037 // indirect
-	gopkg.in/yaml.v2 v2.4.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
 )
@@ -1,6 +1,8 @@
 package goal
 
 import (
+	"fmt"
+	"math/bits"
 	"sync"
 )
 
@@ -19,58 +21,89 @@ func New() *Goal {
 
 const bit58_6 = 0xffffffffffffffc0
 
+// Result tells what happened to a recorded number
+type Result int
+
+const (
+	// Advanced means the number moved the goal (or the highest number of a Window) forward
+	Advanced Result = iota
+	// Buffered means the number is recorded out of order
+	Buffered
+	// Duplicate means the number has been recorded
+	Duplicate
+	// TooOld means the number has fallen out of the Window, so whether it has been recorded is unknown
+	TooOld
+	// TooFar means the number is more than 2^31 ahead and is not recorded
+	TooFar
+)
+
+var resultNames = [...]string{"Advanced", "Buffered", "Duplicate", "TooOld", "TooFar"}
+
+func (r Result) String() string {
+	if r >= 0 && int(r) < len(resultNames) {
+		return resultNames[r]
+	}
+	return fmt.Sprintf("Result(%d)", int(r))
+}
+
+// Meet records num and returns true if it hasn't been recorded
 func (c *Goal) Meet(num uint64) bool {
+	r, _ := c.Record(num)
+	return r == Advanced || r == Buffered
+}
+
+// Record records num and returns the result and how far the goal advanced
+func (c *Goal) Record(num uint64) (Result, uint64) {
 	c.Lock()
 	defer c.Unlock()
 
-	if num == c.goal {
-		c.goal = num + 1
-		return true
-	}
-
 	if num < c.goal {
-		return false
+		return Duplicate, 0
 	}
 
 	if num-c.goal > 1<<31 {
-		return false
+		return TooFar, 0
+	}
+
+	if c.overflowed[num&bit58_6]&(uint64(1)<<(num&0x3f)) > 0 {
+		return Duplicate, 0
+	}
+
+	if num != c.goal {
+		c.overflow++
+		c.overflowed[num&bit58_6] |= uint64(1) << (num & 0x3f)
+		return Buffered, 0
 	}
 
-	c.overflow++
-	score := uint64(0)
-	for i := c.goal; i < num; {
-		x, exist := c.overflowed[i&bit58_6]
-		if !exist {
-			break
+	old := c.goal
+	c.goal = num + 1
+	c.advance()
+	return Advanced, c.goal - old
+}
+
+// advance moves the goal over the numbers recorded right after it and clears their bits
+func (c *Goal) advance() {
+	for {
+		tag, shift := c.goal&bit58_6, c.goal&0x3f
+		x := c.overflowed[tag]
+
+		// the run of set bits from the goal, which ends in this word unless it reaches the next one
+		n := uint64(bits.TrailingZeros64(^(x >> shift)))
+		if n == 0 {
+			return
 		}
 
-	AGAIN:
-		if x&(uint64(1)<<(i&0x3f)) > 0 {
-			score++
-			if (i+1)&bit58_6 == i&bit58_6 && i < num {
-				i++
-				goto AGAIN
-			}
-			i++
+		if x &^= (uint64(1)<<n - 1) << shift; x == 0 {
+			delete(c.overflowed, tag)
 		} else {
-			break
+			c.overflowed[tag] = x
 		}
-	}
 
-	if score == num-c.goal {
-		for i := c.goal; i < num; i++ {
-			tag := i & bit58_6
-			c.overflowed[tag] &= ^(uint64(1) << (i & 0x3f))
-			if c.overflowed[tag] == 0 {
-				delete(c.overflowed, tag)
-			}
+		c.goal += n
+		if c.goal&0x3f != 0 {
+			return
 		}
-		c.goal = num + 1
-		return true
 	}
-
-	c.overflowed[num&bit58_6] |= uint64(1) << (num & 0x3f)
-	return true
 }
 
 func (c *Goal) Goal() uint64 {
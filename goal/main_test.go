@@ -27,9 +27,9 @@ func TestGoal(t *testing.T) {
 		t.FailNow()
 	}
 
+	// 11 is counted in as soon as 10 is met
 	g.Meet(10)
-	// we don't check 11 now, if we add 12 (or onward), we will then cehck 11
-	if g.Goal() != 11 || g.Overflow() != 1 {
+	if g.Goal() != 12 || g.Overflow() != 0 {
 		t.FailNow()
 	}
 
@@ -80,24 +80,7 @@ func BenchmarkGoalRandom(b *testing.B) {
 }
 
 func BenchmarkGoalLessRandom(b *testing.B) {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	x := make([]uint64, 65536)
-	for i := 0; i < len(x); i++ {
-		x[i] = uint64(i)
-	}
-
-	for i := 0; i < len(x); i++ {
-		if r.Intn(100) != 0 {
-			continue
-		}
-
-		j := r.Intn(10) + i - 20
-
-		if j >= 0 && i < len(x) {
-			x[i], x[j] = x[j], x[i]
-		}
-	}
-
+	x := lessRandom()
 	for i := 0; i < b.N; i++ {
 		g := New()
 		for _, num := range x {
@@ -109,3 +92,35 @@ func BenchmarkGoalLessRandom(b *testing.B) {
 		}
 	}
 }
+
+func TestGoalRecord(t *testing.T) {
+	g := New()
+	expect := func(num uint64, r Result, adv uint64) {
+		t.Helper()
+		if rr, a := g.Record(num); rr != r || a != adv {
+			t.Fatal(num, rr, a)
+		}
+	}
+
+	expect(0, Advanced, 1)
+	expect(0, Duplicate, 0)
+	expect(3, Buffered, 0)
+	expect(3, Duplicate, 0)
+	expect(2, Buffered, 0)
+
+	// 2 and 3 recorded right after 1 are counted in
+	expect(1, Advanced, 3)
+	expect(2, Duplicate, 0)
+	expect(4, Advanced, 1)
+	expect(5+1<<31+1, TooFar, 0)
+
+	// runs crossing words of the bitmap
+	for i := uint64(6); i < 200; i++ {
+		expect(i, Buffered, 0)
+	}
+	expect(5, Advanced, 195)
+
+	if g.Goal() != 200 || g.Overflow() != 0 || Buffered.String() != "Buffered" || Result(9).String() != "Result(9)" {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,210 @@
+package goal
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const binaryVersion = 1
+
+// MarshalBinary encodes the goal, the out of order numbers and the counters
+func (c *Goal) MarshalBinary() ([]byte, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	tags := make([]uint64, 0, len(c.overflowed))
+	for tag := range c.overflowed {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i] < tags[j] })
+
+	buf := []byte{binaryVersion}
+	buf = binary.AppendUvarint(buf, c.goal)
+	buf = binary.AppendUvarint(buf, c.overflow)
+	buf = binary.AppendUvarint(buf, uint64(len(tags)))
+
+	// tags are delta encoded in units of 64 numbers
+	prev := uint64(0)
+	for _, tag := range tags {
+		buf = binary.AppendUvarint(buf, (tag-prev)>>6)
+		buf = binary.LittleEndian.AppendUint64(buf, c.overflowed[tag])
+		prev = tag
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary restores the goal encoded by MarshalBinary
+func (c *Goal) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	if v, err := r.ReadByte(); err != nil || v != binaryVersion {
+		return fmt.Errorf("goal: unsupported binary version")
+	}
+
+	var ints [3]uint64
+	for i := range ints {
+		v, err := binary.ReadUvarint(r)
+		if err != nil {
+			return fmt.Errorf("goal: invalid binary: %v", err)
+		}
+		ints[i] = v
+	}
+
+	goal, overflow, n := ints[0], ints[1], ints[2]
+	if n > uint64(r.Len())/9 {
+		return fmt.Errorf("goal: invalid binary: too many tags")
+	}
+
+	overflowed := make(map[uint64]uint64, n)
+	tag := uint64(0)
+	for i := uint64(0); i < n; i++ {
+		d, err := binary.ReadUvarint(r)
+		if err != nil {
+			return fmt.Errorf("goal: invalid binary: %v", err)
+		}
+
+		var word [8]byte
+		if _, err := io.ReadFull(r, word[:]); err != nil {
+			return fmt.Errorf("goal: invalid binary: %v", err)
+		}
+
+		if tag += d << 6; (i > 0 && d == 0) || binary.LittleEndian.Uint64(word[:]) == 0 {
+			return fmt.Errorf("goal: invalid binary: bad tag %d", tag)
+		}
+		overflowed[tag] = binary.LittleEndian.Uint64(word[:])
+	}
+
+	if r.Len() > 0 {
+		return fmt.Errorf("goal: invalid binary: %d trailing bytes", r.Len())
+	}
+
+	c.Lock()
+	c.goal, c.overflow, c.overflowed = goal, overflow, overflowed
+	// numbers right after the goal may be recorded in data saved by older versions
+	c.advance()
+	c.Unlock()
+	return nil
+}
+
+// LoadFile restores the goal saved by SaveFile
+func LoadFile(path string) (*Goal, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := New()
+	if err := c.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// SaveFile saves the goal to path atomically, the file is either the old one or the new one after a crash
+func (c *Goal) SaveFile(path string) error {
+	data, _ := c.MarshalBinary()
+	return writeFile(path, data)
+}
+
+// writeFile writes a temporary file next to path and renames it to path once it is synced
+func writeFile(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+// Checkpoint saves a goal to a file periodically
+type Checkpoint struct {
+	onError func(error)
+	goal    *Goal
+	path    string
+	last    []byte
+	exit    chan struct{}
+	done    chan struct{}
+	sync.Mutex
+}
+
+// Checkpoint saves the goal to path every interval if it has changed, until the checkpoint is closed.
+// onError is called from the saving goroutine when the goal can't be saved, it can be nil.
+func (c *Goal) Checkpoint(path string, interval time.Duration, onError func(error)) *Checkpoint {
+	if interval <= 0 {
+		panic("goal: checkpoint interval must be positive")
+	}
+
+	cp := &Checkpoint{
+		onError: onError,
+		goal:    c,
+		path:    path,
+		exit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		defer close(cp.done)
+
+		for {
+			select {
+			case <-t.C:
+				if err := cp.Save(); err != nil && cp.onError != nil {
+					cp.onError(err)
+				}
+			case <-cp.exit:
+				return
+			}
+		}
+	}()
+
+	return cp
+}
+
+// Save saves the goal immediately if it has changed since the last save
+func (cp *Checkpoint) Save() error {
+	cp.Lock()
+	defer cp.Unlock()
+
+	data, _ := cp.goal.MarshalBinary()
+	if cp.last != nil && bytes.Equal(data, cp.last) {
+		return nil
+	}
+
+	if err := writeFile(cp.path, data); err != nil {
+		return err
+	}
+	cp.last = data
+	return nil
+}
+
+// Close stops saving periodically and saves the goal for the last time
+func (cp *Checkpoint) Close() error {
+	select {
+	case <-cp.exit:
+	default:
+		close(cp.exit)
+	}
+	<-cp.done
+	return cp.Save()
+}
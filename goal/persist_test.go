@@ -0,0 +1,104 @@
+package goal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGoalBinary(t *testing.T) {
+	g := New()
+	for _, num := range []uint64{0, 1, 3, 70, 200, 201} {
+		g.Meet(num)
+	}
+
+	data, err := g.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g2 := New()
+	if err := g2.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if g2.Goal() != 2 || g2.Overflow() != 3 || g2.overflow != g.overflow {
+		t.Fatal(g2.Goal(), g2.Overflow())
+	}
+
+	// the restored goal still rejects duplicates
+	if g2.Meet(70) || g2.Meet(1) || !g2.Meet(2) || !g2.Meet(69) {
+		t.FailNow()
+	}
+
+	// numbers recorded right after the goal in the data are counted in
+	if err := g2.UnmarshalBinary([]byte{1, 2, 0, 1, 0, 0x0c, 0, 0, 0, 0, 0, 0, 0}); err != nil || g2.Goal() != 4 || g2.Overflow() != 0 {
+		t.Fatal(err, g2.Goal())
+	}
+
+	for _, data := range [][]byte{nil, {2}, data[:len(data)-1], append(data, 0), {1, 0, 0, 2, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0}} {
+		if err := New().UnmarshalBinary(data); err == nil {
+			t.Fatal(data)
+		}
+	}
+}
+
+func TestCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goal")
+	if _, err := LoadFile(path); !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+
+	g := New()
+	cp := g.Checkpoint(path, 10*time.Millisecond, nil)
+	g.Meet(0)
+	g.Meet(5)
+
+	// wait for the ticker to save the state
+	var g2 *Goal
+	var err error
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		if g2, err = LoadFile(path); err == nil && g2.Goal() == 1 && !g2.Meet(5) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("state is not saved", err)
+		}
+	}
+
+	// the last state is saved on close
+	g.Meet(1)
+	if err := cp.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if g2, err = LoadFile(path); err != nil || g2.Goal() != 2 {
+		t.Fatal(err)
+	}
+
+	// no temporary files are left
+	if files, _ := filepath.Glob(path + ".*"); len(files) != 0 {
+		t.Fatal(files)
+	}
+}
+
+func TestCheckpointError(t *testing.T) {
+	errs := make(chan error, 1)
+	cp := New().Checkpoint(filepath.Join(t.TempDir(), "missing", "goal"), time.Millisecond, func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	})
+
+	select {
+	case <-errs:
+	case <-time.After(time.Second):
+		t.Fatal("onError is not called")
+	}
+
+	if err := cp.Close(); err == nil {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,144 @@
+package goal
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+// Range is the numbers from Start to End inclusive
+type Range struct {
+	Start, End uint64
+}
+
+func (r Range) String() string {
+	return fmt.Sprintf("%d-%d", r.Start, r.End)
+}
+
+// received returns the goal and the ranges of numbers recorded ahead of it
+func (c *Goal) received() (uint64, []Range) {
+	tags := make([]uint64, 0, len(c.overflowed))
+	for tag := range c.overflowed {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i] < tags[j] })
+
+	var ranges []Range
+	for _, tag := range tags {
+		for x := c.overflowed[tag]; x != 0; x &= x - 1 {
+			num := tag + uint64(bits.TrailingZeros64(x))
+			if num < c.goal {
+				continue
+			}
+
+			if n := len(ranges); n > 0 && ranges[n-1].End+1 == num {
+				ranges[n-1].End = num
+			} else {
+				ranges = append(ranges, Range{num, num})
+			}
+		}
+	}
+	return c.goal, ranges
+}
+
+// Highest returns the highest recorded number, false if none has been recorded
+func (c *Goal) Highest() (uint64, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	goal, ranges := c.received()
+	if len(ranges) > 0 {
+		return ranges[len(ranges)-1].End, true
+	}
+	return goal - 1, goal > 0
+}
+
+// Received returns the ranges of numbers recorded ahead of the goal in ascending order,
+// at most max ranges if max is positive
+func (c *Goal) Received(max int) []Range {
+	c.Lock()
+	defer c.Unlock()
+
+	_, ranges := c.received()
+	if max > 0 && len(ranges) > max {
+		ranges = ranges[:max]
+	}
+	return ranges
+}
+
+// Missing returns the ranges of numbers not recorded between the goal and the highest recorded number
+// in ascending order, at most max ranges if max is positive
+func (c *Goal) Missing(max int) []Range {
+	c.Lock()
+	defer c.Unlock()
+
+	goal, ranges := c.received()
+	var missing []Range
+	for _, r := range ranges {
+		if max > 0 && len(missing) == max {
+			break
+		}
+		missing = append(missing, Range{goal, r.Start - 1})
+		goal = r.End + 1
+	}
+	return missing
+}
+
+// AppendSACK appends a selective acknowledgement to buf: the number below which all numbers have been
+// recorded, followed by at most max ranges (all if max is not positive) recorded ahead of it
+func (c *Goal) AppendSACK(buf []byte, max int) []byte {
+	c.Lock()
+	ack, ranges := c.received()
+	c.Unlock()
+
+	if max > 0 && len(ranges) > max {
+		ranges = ranges[:max]
+	}
+
+	// ranges are encoded as the gap from the end of the previous one and their lengths
+	buf = binary.AppendUvarint(buf, ack)
+	buf = binary.AppendUvarint(buf, uint64(len(ranges)))
+	prev := ack
+	for _, r := range ranges {
+		buf = binary.AppendUvarint(buf, r.Start-prev)
+		buf = binary.AppendUvarint(buf, r.End-r.Start)
+		prev = r.End + 1
+	}
+	return buf
+}
+
+// DecodeSACK decodes the selective acknowledgement encoded by AppendSACK and returns the number of bytes read
+func DecodeSACK(data []byte) (ack uint64, ranges []Range, n int, err error) {
+	r := bytes.NewReader(data)
+	read := func() uint64 {
+		v, e := binary.ReadUvarint(r)
+		if e != nil && err == nil {
+			err = fmt.Errorf("goal: invalid SACK: %v", e)
+		}
+		return v
+	}
+
+	ack = read()
+	count := read()
+	if err == nil && count > uint64(r.Len())/2 {
+		return 0, nil, 0, fmt.Errorf("goal: invalid SACK: too many ranges")
+	}
+
+	prev := ack
+	for i := uint64(0); i < count && err == nil; i++ {
+		gap, length := read(), read()
+		if err == nil && (gap == 0 || prev+gap < prev || prev+gap+length < prev+gap) {
+			err = fmt.Errorf("goal: invalid SACK: bad range")
+		}
+
+		ranges = append(ranges, Range{prev + gap, prev + gap + length})
+		prev = prev + gap + length + 1
+	}
+
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	return ack, ranges, len(data) - r.Len(), nil
+}
@@ -0,0 +1,67 @@
+package goal
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGoalMissing(t *testing.T) {
+	g := New()
+	if _, ok := g.Highest(); ok || g.Missing(0) != nil || g.Received(0) != nil {
+		t.FailNow()
+	}
+
+	for _, num := range []uint64{0, 1, 3, 4, 10, 64, 65, 63, 200} {
+		g.Meet(num)
+	}
+
+	if h, ok := g.Highest(); !ok || h != 200 {
+		t.Fatal(h)
+	}
+
+	if r := fmt.Sprint(g.Received(0)); r != "[3-4 10-10 63-65 200-200]" {
+		t.Fatal(r)
+	}
+
+	if m := fmt.Sprint(g.Missing(0)); m != "[2-2 5-9 11-62 66-199]" {
+		t.Fatal(m)
+	}
+
+	if m := fmt.Sprint(g.Missing(2)); m != "[2-2 5-9]" {
+		t.Fatal(m)
+	}
+
+	// 2 recorded before 1 is counted in with it, 3 and 4 are missing
+	g = New()
+	g.Meet(0)
+	g.Meet(2)
+	g.Meet(1)
+	g.Meet(5)
+	if h, _ := g.Highest(); g.Goal() != 3 || h != 5 || fmt.Sprint(g.Missing(0)) != "[3-4]" {
+		t.Fatal(g.Missing(0))
+	}
+}
+
+func TestSACK(t *testing.T) {
+	g := New()
+	for _, num := range []uint64{0, 1, 3, 4, 10, 64, 65, 63, 200} {
+		g.Meet(num)
+	}
+
+	buf := g.AppendSACK([]byte{0xff}, 3)
+	ack, ranges, n, err := DecodeSACK(append(buf[1:], 0xee))
+	if err != nil || ack != 2 || n != len(buf)-1 || fmt.Sprint(ranges) != "[3-4 10-10 63-65]" {
+		t.Fatal(ack, ranges, n, err)
+	}
+
+	ack, ranges, _, err = DecodeSACK(New().AppendSACK(nil, 0))
+	if err != nil || ack != 0 || ranges != nil {
+		t.Fatal(ack, ranges, err)
+	}
+
+	for _, data := range [][]byte{nil, {2}, buf[1 : len(buf)-1], {1, 1, 0, 0}, {1, 200, 1, 0}} {
+		if _, _, _, err := DecodeSACK(data); err == nil {
+			t.Fatal(data)
+		}
+	}
+}
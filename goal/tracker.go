@@ -0,0 +1,100 @@
+package goal
+
+import (
+	"sync"
+
+	"github.com/coyove/common/lru"
+)
+
+const trackerShards = 16
+
+// Tracker holds a Window per stream. Streams least recently used are evicted when the windows
+// take more memory than allowed, numbers of an evicted stream are accepted again as a new stream.
+type Tracker struct {
+	size   int
+	shards [trackerShards]struct {
+		cache *lru.Cache
+		sync.Mutex
+	}
+}
+
+// NewTracker creates a tracker of windows of windowSize numbers taking at most maxBytes memory,
+// it panics if maxBytes can't hold a window in each of the 16 shards of the tracker.
+// onEvicted is called with the ID of a stream being evicted, it can be nil.
+func NewTracker(maxBytes int64, windowSize int, onEvicted func(stream uint64)) *Tracker {
+	t := &Tracker{size: windowSize}
+	weight := windowWeight(NewWindow(windowSize))
+	perShard := maxBytes / trackerShards
+	if perShard < weight {
+		panic("goal: tracker memory can't hold a window per shard")
+	}
+
+	for i := range t.shards {
+		c := lru.NewCache(perShard)
+		if onEvicted != nil {
+			c.OnEvicted = func(key lru.Key, value interface{}) { onEvicted(key.(uint64)) }
+		}
+		t.shards[i].cache = c
+	}
+	return t
+}
+
+// windowWeight returns the approximate memory taken by w
+func windowWeight(w *Window) int64 {
+	return int64(len(w.slots))*8 + 64
+}
+
+func (t *Tracker) shard(stream uint64) int {
+	return int((stream * 0x9e3779b97f4a7c15) >> 60)
+}
+
+// window returns the window of stream, it is created if create is true
+func (t *Tracker) window(stream uint64, create bool) *Window {
+	s := &t.shards[t.shard(stream)]
+	s.Lock()
+	defer s.Unlock()
+
+	if w, ok := s.cache.Get(stream); ok {
+		return w.(*Window)
+	}
+
+	if !create {
+		return nil
+	}
+
+	w := NewWindow(t.size)
+	s.cache.AddWeight(stream, w, windowWeight(w))
+	return w
+}
+
+// Meet records num of stream and returns true if it hasn't been met
+func (t *Tracker) Meet(stream, num uint64) bool {
+	return t.window(stream, true).Meet(num)
+}
+
+// Record records num of stream like Window.Record
+func (t *Tracker) Record(stream, num uint64) (Result, uint64) {
+	return t.window(stream, true).Record(num)
+}
+
+// Window returns the window of stream, nil if the stream is not tracked
+func (t *Tracker) Window(stream uint64) *Window {
+	return t.window(stream, false)
+}
+
+// Forget stops tracking stream without calling onEvicted
+func (t *Tracker) Forget(stream uint64) {
+	s := &t.shards[t.shard(stream)]
+	s.Lock()
+	s.cache.RemoveSlient(stream)
+	s.Unlock()
+}
+
+// Len returns the number of tracked streams
+func (t *Tracker) Len() int {
+	n := 0
+	for i := range t.shards {
+		n += t.shards[i].cache.Len()
+	}
+	return n
+}
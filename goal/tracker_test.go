@@ -0,0 +1,95 @@
+package goal
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestTracker(t *testing.T) {
+	weight := windowWeight(NewWindow(64))
+	var evicted []uint64
+	tr := NewTracker(weight*trackerShards*2, 64, func(stream uint64) { evicted = append(evicted, stream) })
+
+	if !tr.Meet(1, 0) || !tr.Meet(2, 0) || tr.Meet(1, 0) || tr.Window(3) != nil {
+		t.FailNow()
+	}
+
+	if r, adv := tr.Record(1, 5); r != Advanced || adv != 5 {
+		t.Fatal(r, adv)
+	}
+
+	tr.Forget(2)
+	if tr.Window(2) != nil || !tr.Meet(2, 0) || tr.Len() != 2 {
+		t.FailNow()
+	}
+
+	// each shard holds 2 windows, the least recently used ones are evicted
+	evicted = nil
+	for s := uint64(100); s < 1000; s++ {
+		tr.Meet(s, 0)
+	}
+
+	if tr.Len() != 2*trackerShards || len(evicted) != 902-2*trackerShards {
+		t.Fatal(tr.Len(), len(evicted))
+	}
+
+	if tr.Window(1) != nil || tr.Window(999) == nil {
+		t.FailNow()
+	}
+
+	// an evicted stream starts over
+	if !tr.Meet(1, 0) {
+		t.FailNow()
+	}
+
+	// streams can start at any number
+	if !tr.Meet(7, 1<<32) || tr.Meet(7, 1<<32) || !tr.Meet(7, 1<<32+1) {
+		t.FailNow()
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("a shard can't hold a window")
+		}
+	}()
+	NewTracker(weight*trackerShards-1, 64, nil)
+}
+
+func TestTrackerConcurrent(t *testing.T) {
+	tr := NewTracker(1<<20, 128, nil)
+	var wg sync.WaitGroup
+	met := make([]int, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for s := uint64(0); s < 100; s++ {
+				for num := uint64(0); num < 100; num++ {
+					if tr.Meet(s, num) {
+						met[i]++
+					}
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	total := 0
+	for _, n := range met {
+		total += n
+	}
+	if total != 100*100 {
+		t.Fatal(total)
+	}
+}
+
+func BenchmarkTrackerParallel(b *testing.B) {
+	tr := NewTracker(1<<24, 1024, nil)
+	b.RunParallel(func(pb *testing.PB) {
+		num := uint64(0)
+		for pb.Next() {
+			tr.Meet(num%1000, num)
+			num++
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package goal
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// Window is a lock-free sliding window of met numbers, like the anti-replay windows of TCP and IPsec.
+// Unlike Goal, numbers fall out of the window once they are Size() or more behind the highest met one,
+// so its memory is fixed however the numbers are reordered or lost.
+type Window struct {
+	high  uint64 // the highest met number, valid once state is windowStarted
+	state uint32
+	size  uint64
+
+	// slots is a ring of blocks of 32 numbers, each slot holds the low 32 bits of its block number
+	// in its high half and the bitmap of met numbers in its low half, so both are swapped in one CAS
+	slots []uint64
+}
+
+const (
+	windowEmpty uint32 = iota
+	windowStarting
+	windowStarted
+)
+
+// NewWindow creates a window of at least size numbers, size is rounded up to a multiple of 32
+func NewWindow(size int) *Window {
+	if size <= 0 {
+		panic("goal: window size must be positive")
+	}
+
+	n := (size + 31) / 32
+	// an extra slot, so the block of the highest number doesn't take the place of the oldest one in the window
+	return &Window{size: uint64(n) * 32, slots: make([]uint64, n+1)}
+}
+
+// Size returns the number of numbers in the window
+func (w *Window) Size() int {
+	return int(w.size)
+}
+
+// Highest returns the highest met number, false if none has been met
+func (w *Window) Highest() (uint64, bool) {
+	if atomic.LoadUint32(&w.state) != windowStarted {
+		return 0, false
+	}
+	return atomic.LoadUint64(&w.high), true
+}
+
+// newer reports whether block a is newer than the block tagged t
+func newer(a uint64, t uint32) bool {
+	return int32(uint32(a)-t) > 0
+}
+
+// Meet records num and returns true if it hasn't been met. Numbers Size() or more behind the highest met one
+// and numbers more than 2^31 ahead of it are rejected, the first number is always accepted. It is safe to call Meet concurrently.
+func (w *Window) Meet(num uint64) bool {
+	r, _ := w.Record(num)
+	return r == Advanced || r == Buffered
+}
+
+// Record is like Meet but returns the result and how far the highest met number advanced
+func (w *Window) Record(num uint64) (Result, uint64) {
+	block, bit := num>>5, uint32(1)<<(num&31)
+
+	// the first number can be anything, e.g. a random initial sequence number, it is set as the highest one
+	// by the caller winning the state, others wait for it
+	for state := atomic.LoadUint32(&w.state); state != windowStarted; state = atomic.LoadUint32(&w.state) {
+		if state == windowEmpty && atomic.CompareAndSwapUint32(&w.state, windowEmpty, windowStarting) {
+			atomic.StoreUint64(&w.high, num)
+			atomic.StoreUint32(&w.state, windowStarted)
+			if r := w.claim(block, bit); r != Buffered {
+				return r, 0
+			}
+			return Advanced, num + 1
+		}
+		runtime.Gosched()
+	}
+
+	high := atomic.LoadUint64(&w.high)
+	if high >= w.size && num <= high-w.size {
+		return TooOld, 0
+	}
+
+	if num > high && num-high-1 > 1<<31 {
+		return TooFar, 0
+	}
+
+	n := uint64(len(w.slots))
+	advanced := uint64(0)
+	for num > high {
+		if !atomic.CompareAndSwapUint64(&w.high, high, num) {
+			high = atomic.LoadUint64(&w.high)
+			continue
+		}
+
+		// clear the slots of the blocks entering the window, the ones skipped would keep stale bits
+		from := high>>5 + 1
+		if block >= n && from < block-n+1 {
+			from = block - n + 1
+		}
+		for b := from; b < block; b++ {
+			w.claim(b, 0)
+		}
+		advanced = num - high
+		break
+	}
+
+	r := w.claim(block, bit)
+	if r == Buffered && advanced > 0 {
+		return Advanced, advanced
+	}
+	return r, 0
+}
+
+// claim sets bit in the slot of block and returns Buffered, or Duplicate if it has been set.
+// A slot holding an older block is taken over by block, and TooOld is returned if it holds a newer one.
+func (w *Window) claim(block uint64, bit uint32) Result {
+	p := &w.slots[block%uint64(len(w.slots))]
+	for {
+		old := atomic.LoadUint64(p)
+		t := uint32(old >> 32)
+
+		var v uint64
+		switch {
+		case t == uint32(block):
+			if uint32(old)&bit != 0 {
+				return Duplicate
+			}
+			v = old | uint64(bit)
+		case old == 0 || newer(block, t):
+			// 0 is an unused slot, which would look newer than blocks whose low 32 bits are 2^31 or more
+			v = uint64(uint32(block))<<32 | uint64(bit)
+		default:
+			return TooOld
+		}
+
+		if atomic.CompareAndSwapUint64(p, old, v) {
+			return Buffered
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package goal
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWindow(t *testing.T) {
+	w := NewWindow(100)
+	if w.Size() != 128 {
+		t.FailNow()
+	}
+
+	if _, ok := w.Highest(); ok {
+		t.FailNow()
+	}
+
+	for _, num := range []uint64{0, 1, 5, 3, 200, 73, 199} {
+		if !w.Meet(num) {
+			t.Fatal(num)
+		}
+	}
+
+	// duplicates, too old and too far
+	for _, num := range []uint64{0, 5, 73, 200, 72, 1 << 32} {
+		if w.Meet(num) {
+			t.Fatal(num)
+		}
+	}
+
+	if h, ok := w.Highest(); !ok || h != 200 {
+		t.Fatal(h)
+	}
+
+	// numbers are forgotten after the window slides over them
+	if !w.Meet(1000) || w.Meet(199) || !w.Meet(1000-127) || w.Meet(1000-128) {
+		t.FailNow()
+	}
+
+	// slots skipped by a long jump don't keep their bits
+	if !w.Meet(1000+32*5) || !w.Meet(1000+32*5-64) {
+		t.FailNow()
+	}
+}
+
+func TestWindowRecord(t *testing.T) {
+	w := NewWindow(64)
+	for _, c := range []struct {
+		num uint64
+		r   Result
+		adv uint64
+	}{
+		{0, Advanced, 1}, {10, Advanced, 10}, {5, Buffered, 0}, {5, Duplicate, 0},
+		{100, Advanced, 90}, {36, TooOld, 0}, {37, Buffered, 0}, {1 << 40, TooFar, 0},
+	} {
+		if r, adv := w.Record(c.num); r != c.r || adv != c.adv {
+			t.Fatal(c.num, r, adv)
+		}
+	}
+}
+
+func TestWindowLargeStart(t *testing.T) {
+	w := NewWindow(64)
+	if r, adv := w.Record(3000000000); r != Advanced || adv != 3000000001 {
+		t.Fatal(r, adv)
+	}
+
+	if w.Meet(3000000000) || !w.Meet(2999999999) || !w.Meet(3000000001) || w.Meet(3000000000-64) || w.Meet(1<<40) {
+		t.FailNow()
+	}
+
+	// unused slots are taken by any block
+	start := uint64(0xf0000000)<<5 | 3
+	w = NewWindow(64)
+	if !w.Meet(start) || w.Meet(start) || !w.Meet(start-40) || !w.Meet(start+100) {
+		t.FailNow()
+	}
+}
+
+func TestWindowMaxUint64(t *testing.T) {
+	w := NewWindow(64)
+	if r, adv := w.Record(math.MaxUint64 - 1); r != Advanced || adv != math.MaxUint64 {
+		t.Fatal(r, adv)
+	}
+
+	// the highest number doesn't wrap to an empty window
+	if r, adv := w.Record(math.MaxUint64); r != Advanced || adv != 1 {
+		t.Fatal(r, adv)
+	}
+	if h, ok := w.Highest(); !ok || h != math.MaxUint64 {
+		t.Fatal(h, ok)
+	}
+
+	if w.Meet(math.MaxUint64) || w.Meet(math.MaxUint64-1) || !w.Meet(math.MaxUint64-63) || w.Meet(math.MaxUint64-64) || w.Meet(0) {
+		t.FailNow()
+	}
+
+	w = NewWindow(64)
+	if !w.Meet(math.MaxUint64) || w.Meet(math.MaxUint64) || !w.Meet(math.MaxUint64-1) {
+		t.FailNow()
+	}
+}
+
+func TestWindowRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	x := r.Perm(65536)
+	w := NewWindow(65536)
+	for _, num := range x {
+		if !w.Meet(uint64(num)) {
+			t.Fatal(num)
+		}
+	}
+
+	for i := 0; i < 65536; i++ {
+		if w.Meet(uint64(i)) {
+			t.Fatal(i)
+		}
+	}
+}
+
+func TestWindowConcurrent(t *testing.T) {
+	w := NewWindow(1024)
+	var met int64
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+			for j := 0; j < 100000; j++ {
+				// every number is met by some goroutines, no later than 512 after the highest
+				num := uint64(j/8*8 + r.Intn(8))
+				if w.Meet(num) {
+					atomic.AddInt64(&met, 1)
+				}
+			}
+		}(int64(i))
+	}
+	wg.Wait()
+
+	// each number is accepted at most once
+	if met > 100000 {
+		t.Fatal(met)
+	}
+}
+
+func BenchmarkWindowRandom(b *testing.B) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	x := r.Perm(65536)
+	for i := 0; i < b.N; i++ {
+		w := NewWindow(65536)
+		for _, num := range x {
+			w.Meet(uint64(num))
+		}
+	}
+}
+
+func BenchmarkWindowLessRandom(b *testing.B) {
+	x := lessRandom()
+	for i := 0; i < b.N; i++ {
+		w := NewWindow(1024)
+		for _, num := range x {
+			w.Meet(num)
+		}
+	}
+}
+
+func BenchmarkGoalParallel(b *testing.B) {
+	g := New()
+	var n uint64
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			g.Meet(atomic.AddUint64(&n, 1) - 1)
+		}
+	})
+}
+
+func BenchmarkWindowParallel(b *testing.B) {
+	w := NewWindow(1024)
+	var n uint64
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			w.Meet(atomic.AddUint64(&n, 1) - 1)
+		}
+	})
+}
+
+// lessRandom returns 0 to 65535 with a few numbers swapped with nearby ones
+func lessRandom() []uint64 {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	x := make([]uint64, 65536)
+	for i := range x {
+		x[i] = uint64(i)
+	}
+
+	for i := range x {
+		if r.Intn(100) == 0 {
+			if j := r.Intn(10) + i - 20; j >= 0 {
+				x[i], x[j] = x[j], x[i]
+			}
+		}
+	}
+	return x
+}
@@ -65,50 +65,81 @@ func NewString(extra string) string {
 
 // Consume validates the token and consumes it (if true)
 func Consume(tok [16]byte, extra string) bool {
-	repository.blk.Decrypt(tok[:], tok[:])
-	x := sha1.Sum(tok[:8])
-	if !bytes.Equal(x[:8], tok[8:]) {
+	tok, ok := open(tok)
+	if !ok || string(tok[4:8]) != extra[:4] {
 		return false
 	}
+	return burn(tok)
+}
 
-	if string(tok[4:8]) != extra[:4] {
-		return false
+// ConsumeString validates the token and consumes it (if true)
+func ConsumeString(tok string, extra string) bool {
+	t, ok := parseString(tok)
+	return ok && Consume(t, extra)
+}
+
+// Take validates the token and consumes it whatever extra it carries, the extra is returned
+// so the caller can check it after the token can no longer be used
+func Take(tok [16]byte) (extra string, ok bool) {
+	tok, ok = open(tok)
+	if !ok || !burn(tok) {
+		return "", false
+	}
+	return string(tok[4:8]), true
+}
+
+// TakeString validates the string token and consumes it, see Take
+func TakeString(tok string) (extra string, ok bool) {
+	t, ok := parseString(tok)
+	if !ok {
+		return "", false
+	}
+	return Take(t)
+}
+
+// open decrypts the token and checks whether it is intact and not expired
+func open(tok [16]byte) ([16]byte, bool) {
+	repository.blk.Decrypt(tok[:], tok[:])
+	x := sha1.Sum(tok[:8])
+	if !bytes.Equal(x[:8], tok[8:]) {
+		return tok, false
 	}
 
 	now := uint32(time.Now().Unix())
 	ts := binary.LittleEndian.Uint32(tok[:4])
 
 	if now < ts {
-		return false
+		return tok, false
 	}
 	if now-ts > TTL {
-		return false
+		return tok, false
 	}
+	return tok, true
+}
 
+// burn marks the decrypted token as used, it returns false if it has been used
+func burn(tok [16]byte) bool {
 	repository.Lock()
+	defer repository.Unlock()
 	if repository.oldTokens[tok] {
-		repository.Unlock()
 		return false
 	}
 	repository.oldTokens[tok] = true
-	repository.Unlock()
 	return true
 }
 
-// ConsumeString validates the token and consumes it (if true)
-func ConsumeString(tok string, extra string) bool {
+func parseString(tok string) ([16]byte, bool) {
+	var t [16]byte
 	if len(tok) != 32 {
-		return false
+		return t, false
 	}
 
-	var t [16]byte
 	for i := 0; i < 16; i++ {
 		n, err := strconv.ParseInt(tok[i*2:i*2+2], 16, 64)
 		if err != nil {
-			return false
+			return t, false
 		}
 		t[i] = byte(n)
 	}
-
-	return Consume(t, extra)
+	return t, true
 }